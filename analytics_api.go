@@ -0,0 +1,34 @@
+package appsearch
+
+import (
+	"context"
+	"net/http"
+)
+
+// Log click on a document returned by search
+func (c *client) LogClickthrough(ctx context.Context, engineName string, request ClickRequest) (err error) {
+	err = c.Call(ctx, request, nil, http.MethodPost, "engines/%s/click", engineName)
+
+	return
+}
+
+// Number of queries and clicks by interval
+func (c *client) AnalyticsCounts(ctx context.Context, engineName string, request AnalyticsCountsRequest) (data AnalyticsCountsResponse, err error) {
+	err = c.Call(ctx, request, &data, http.MethodGet, "engines/%s/analytics/counts", engineName)
+
+	return data, err
+}
+
+// Top queries filtered by date, tags, presence of results or clicks
+func (c *client) TopQueries(ctx context.Context, engineName string, request TopQueriesRequest) (data TopQueriesResponse, err error) {
+	err = c.Call(ctx, request, &data, http.MethodGet, "engines/%s/analytics/queries", engineName)
+
+	return data, err
+}
+
+// Top clicked documents, optionally for a single query
+func (c *client) TopClicks(ctx context.Context, engineName string, request TopClicksRequest) (data TopClicksResponse, err error) {
+	err = c.Call(ctx, request, &data, http.MethodGet, "engines/%s/analytics/clicks", engineName)
+
+	return data, err
+}
@@ -0,0 +1,133 @@
+package appsearch
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyticsAPI(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	c, err := Open(os.Getenv("APPSEARCH"))
+	require.NoError(t, err)
+
+	t.Run("Must log clickthrough", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		_, err := c.UpdateDocuments(ctx, engine.Name, []m{
+			{"id": "national-parks", "title": "Amazing title"},
+		})
+		require.NoError(t, err)
+
+		time.Sleep(time.Second)
+
+		response, err := c.SearchDocuments(ctx, engine.Name, Query{Query: "amazing"})
+		require.NoError(t, err)
+
+		err = c.LogClickthrough(ctx, engine.Name, ClickRequest{
+			Query:      "amazing",
+			DocumentID: "national-parks",
+			RequestID:  response.Meta.RequestID,
+			Tags:       []string{"test"},
+		})
+		require.NoError(t, err)
+	})
+	t.Run("Must list analytics counts", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		response, err := c.AnalyticsCounts(ctx, engine.Name, AnalyticsCountsRequest{
+			Filters: &AnalyticsFilters{
+				Date: &Range{
+					From: time.Now().Add(-24 * time.Hour).Format(time.RFC3339),
+					To:   time.Now().Format(time.RFC3339),
+				},
+			},
+			Interval: HourInterval,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, response.Results)
+	})
+
+	t.Run("Must list top queries without results", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		_, err := c.SearchDocuments(ctx, engine.Name, Query{Query: "nothing"})
+		require.NoError(t, err)
+
+		withResults := false
+		_, err = c.TopQueries(ctx, engine.Name, TopQueriesRequest{
+			Filters: &AnalyticsFilters{Results: &withResults},
+			Page:    &Page{Size: 10},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Must list top clicks", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		_, err := c.TopClicks(ctx, engine.Name, TopClicksRequest{
+			Query:   "amazing",
+			Filters: &AnalyticsFilters{Tags: []string{"test"}},
+			Page:    &Page{Size: 10},
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestAnalyticsFilters(t *testing.T) {
+	t.Run("Must marshal filters combined with all", func(t *testing.T) {
+		withClicks := false
+		data, err := json.Marshal(AnalyticsFilters{
+			Date:   &Range{From: "2021-01-01T00:00:00Z"},
+			Tags:   []string{"mobile"},
+			Clicks: &withClicks,
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"all": [
+			{"date": {"from": "2021-01-01T00:00:00Z"}},
+			{"tag": ["mobile"]},
+			{"clicks": false}
+		]}`, string(data))
+	})
+	t.Run("Must send filters and page to server", func(t *testing.T) {
+		c, requests := recordingClient(t, func(r recordedRequest) interface{} {
+			return m{}
+		})
+		ctx := context.TODO()
+		withResults := false
+		filters := &AnalyticsFilters{Tags: []string{"mobile"}, Results: &withResults}
+
+		_, err := c.AnalyticsCounts(ctx, "engine", AnalyticsCountsRequest{Filters: filters, Interval: HourInterval})
+		require.NoError(t, err)
+		_, err = c.TopQueries(ctx, "engine", TopQueriesRequest{Filters: filters, Page: &Page{Size: 20}})
+		require.NoError(t, err)
+		_, err = c.TopClicks(ctx, "engine", TopClicksRequest{Query: "parks", Filters: filters, Page: &Page{Size: 20}})
+		require.NoError(t, err)
+
+		filtersJSON := `{"all": [{"tag": ["mobile"]}, {"results": false}]}`
+		expected := []recordedRequest{
+			{Path: "engines/engine/analytics/counts", Body: `{"filters": ` + filtersJSON + `, "interval": "hour"}`},
+			{Path: "engines/engine/analytics/queries", Body: `{"filters": ` + filtersJSON + `, "page": {"size": 20}}`},
+			{Path: "engines/engine/analytics/clicks", Body: `{"query": "parks", "filters": ` + filtersJSON + `, "page": {"size": 20}}`},
+		}
+		require.Len(t, requests(), len(expected))
+		for i, request := range requests() {
+			require.Equal(t, expected[i].Path, request.Path)
+			require.JSONEq(t, expected[i].Body, request.Body)
+		}
+	})
+}
@@ -4,42 +4,71 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-resty/resty/v2"
+
+	"github.com/lithiumlabcompany/appsearch/internal/pkg/ratelimit"
 )
 
-type client struct{ *resty.Client }
+type client struct {
+	*resty.Client
+	retry RetryPolicy
+	// Limiters of read (searches and listings) and write requests
+	readLimiter  *ratelimit.Limiter
+	writeLimiter *ratelimit.Limiter
+}
 
 func (c *client) Call(ctx context.Context, requestBody, resultPtr interface{}, method, urlFormat string, args ...interface{}) error {
-	r, err := c.request(ctx, requestBody, resultPtr).
-		Execute(method, fmt.Sprintf(urlFormat, args...))
-	if err != nil {
-		return err
+	retryable := c.retry.retryable(method, urlFormat)
+	limiter := c.writeLimiter
+	if classifyEndpoint(method, urlFormat) == readEndpoint {
+		limiter = c.readLimiter
 	}
 
-	if r.IsError() {
-		err := r.Error().(*Error)
-		err.StatusCode = r.StatusCode()
-		// Map error to known api errors for convenience
-		if err, ok := apiErrors[err.Error()]; ok {
+	for attempt := 1; ; attempt++ {
+		canRetry := retryable && attempt < c.retry.MaxAttempts
+
+		if err := limiter.Wait(ctx); err != nil {
 			return err
 		}
-		return err
-	}
 
-	if resultPtr != nil {
-		outElem := reflect.ValueOf(resultPtr).Elem()
-		resultElem := reflect.ValueOf(r.Result()).Elem()
+		r, err := c.request(ctx, requestBody, resultPtr).
+			Execute(method, fmt.Sprintf(urlFormat, args...))
+		if err != nil {
+			if canRetry && networkError(ctx, err) {
+				if err := sleep(ctx, c.retry.backoff(attempt, 0)); err != nil {
+					return err
+				}
+				continue
+			}
+			if attempt > 1 {
+				return fmt.Errorf("after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
 
-		if outElem.Type() != resultElem.Type() {
-			return fmt.Errorf("cannot assign result: different types: %s != %s",
-				outElem.Type().String(), resultElem.Type().String())
+		if r.IsError() {
+			retryAfter := parseRetryAfter(r.Header().Get("Retry-After"), time.Now())
+			if canRetry && retryableStatus(r.StatusCode()) && c.retry.allowsRetryAfter(retryAfter) {
+				if err := sleep(ctx, c.retry.backoff(attempt, retryAfter)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := r.Error().(*Error)
+			err.StatusCode = r.StatusCode()
+			err.Attempts = attempt
+			// Map error to known api errors for convenience
+			if err, ok := apiErrors[err.Error()]; ok {
+				return err
+			}
+			return err
 		}
 
-		outElem.Set(resultElem)
+		return assignResult(r, resultPtr)
 	}
-
-	return nil
 }
 
 func (c *client) request(ctx context.Context, requestBody interface{}, resultPtr interface{}) *resty.Request {
@@ -54,3 +83,19 @@ func (c *client) request(ctx context.Context, requestBody interface{}, resultPtr
 
 	return req
 }
+
+func assignResult(r *resty.Response, resultPtr interface{}) error {
+	if resultPtr != nil {
+		outElem := reflect.ValueOf(resultPtr).Elem()
+		resultElem := reflect.ValueOf(r.Result()).Elem()
+
+		if outElem.Type() != resultElem.Type() {
+			return fmt.Errorf("cannot assign result: different types: %s != %s",
+				outElem.Type().String(), resultElem.Type().String())
+		}
+
+		outElem.Set(resultElem)
+	}
+
+	return nil
+}
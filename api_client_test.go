@@ -1,7 +1,14 @@
 package appsearch
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch/internal/pkg/ratelimit"
 )
 
 func TestClient(t *testing.T) {
@@ -9,4 +16,33 @@ func TestClient(t *testing.T) {
 		var c APIClient = &client{}
 		_ = c
 	})
+
+	t.Run("Must map 429 to ErrRateLimited", func(t *testing.T) {
+		c, _ := retryingClient(t, RetryPolicy{}, nil, 429)
+		_, err := c.ListEngine(context.TODO(), "engine")
+		require.ErrorIs(t, err, ErrRateLimited)
+
+		c, _ = retryingClient(t, RetryPolicy{}, nil, 503)
+		_, err = c.ListEngine(context.TODO(), "engine")
+		require.False(t, errors.Is(err, ErrRateLimited))
+	})
+
+	t.Run("Must limit read and write requests separately", func(t *testing.T) {
+		c, requests := retryingClient(t, RetryPolicy{}, nil, 200)
+		c.readLimiter = ratelimit.New(1, 1)
+		c.writeLimiter = ratelimit.New(1, 1)
+		ctx := context.TODO()
+
+		_, err := c.ListEngine(ctx, "engine")
+		require.NoError(t, err)
+		_, err = c.CreateEngine(ctx, CreateEngineRequest{Name: "engine"})
+		require.NoError(t, err)
+
+		// Read budget is exhausted
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = c.ListEngine(timeout, "engine")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.EqualValues(t, 2, *requests)
+	})
 }
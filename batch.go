@@ -0,0 +1,74 @@
+package appsearch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const (
+	// MaxBatchDocuments Maximum number of documents accepted by API in a single write request
+	MaxBatchDocuments = 100
+	// MaxBatchSize Maximum payload size (in bytes) accepted by API in a single write request
+	MaxBatchSize = 10 << 20
+)
+
+// Split documents (or ID's) into batches within MaxBatchDocuments and MaxBatchSize limits.
+// Documents may be specified as a slice, a single document or marshaled JSON.
+func splitBatches(documents interface{}) (batches [][]json.RawMessage, err error) {
+	items, err := rawDocuments(documents)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []json.RawMessage
+	// Opening and closing brackets of JSON array
+	size := 2
+
+	for _, item := range items {
+		// Item with a separating comma
+		itemSize := len(item) + 1
+
+		if len(batch) > 0 && (len(batch) == MaxBatchDocuments || size+itemSize > MaxBatchSize) {
+			batches = append(batches, batch)
+			batch = nil
+			size = 2
+		}
+
+		batch = append(batch, item)
+		size += itemSize
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// Documents as a list of raw JSON values
+func rawDocuments(documents interface{}) (items []json.RawMessage, err error) {
+	var data []byte
+
+	switch documents := documents.(type) {
+	case []byte:
+		data = documents
+	case json.RawMessage:
+		data = documents
+	default:
+		data, err = json.Marshal(documents)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0, bytes.Equal(data, []byte("null")):
+		return nil, nil
+	case data[0] == '[':
+		err = json.Unmarshal(data, &items)
+		return items, err
+	default:
+		return []json.RawMessage{data}, nil
+	}
+}
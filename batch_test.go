@@ -0,0 +1,74 @@
+package appsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBatches(t *testing.T) {
+	t.Run("Must split documents by count", func(t *testing.T) {
+		documents := make([]m, 250)
+		for i := range documents {
+			documents[i] = m{"id": fmt.Sprintf("%d", i)}
+		}
+
+		batches, err := splitBatches(documents)
+		require.NoError(t, err)
+		require.Len(t, batches, 3)
+		require.Len(t, batches[0], MaxBatchDocuments)
+		require.Len(t, batches[1], MaxBatchDocuments)
+		require.Len(t, batches[2], 50)
+		require.JSONEq(t, `{"id": "249"}`, string(batches[2][49]))
+	})
+
+	t.Run("Must split documents by payload size", func(t *testing.T) {
+		text := strings.Repeat("a", MaxBatchSize/4)
+		documents := []m{{"text": text}, {"text": text}, {"text": text}, {"text": text}}
+
+		batches, err := splitBatches(documents)
+		require.NoError(t, err)
+		require.Len(t, batches, 2)
+		require.Len(t, batches[0], 3)
+		require.Len(t, batches[1], 1)
+	})
+
+	t.Run("Must split marshaled JSON", func(t *testing.T) {
+		batches, err := splitBatches([]byte(`[{"id": "a"}, {"id": "b"}]`))
+		require.NoError(t, err)
+		require.EqualValues(t, [][]json.RawMessage{{
+			json.RawMessage(`{"id": "a"}`),
+			json.RawMessage(`{"id": "b"}`),
+		}}, batches)
+	})
+
+	t.Run("Must split ID's", func(t *testing.T) {
+		batches, err := splitBatches([]string{"a", "b"})
+		require.NoError(t, err)
+		require.EqualValues(t, [][]json.RawMessage{{
+			json.RawMessage(`"a"`),
+			json.RawMessage(`"b"`),
+		}}, batches)
+	})
+
+	t.Run("Must wrap single document", func(t *testing.T) {
+		batches, err := splitBatches(m{"id": "a"})
+		require.NoError(t, err)
+		require.EqualValues(t, [][]json.RawMessage{{
+			json.RawMessage(`{"id":"a"}`),
+		}}, batches)
+	})
+
+	t.Run("Must return no batches for no documents", func(t *testing.T) {
+		batches, err := splitBatches(nil)
+		require.NoError(t, err)
+		require.Empty(t, batches)
+
+		batches, err = splitBatches([]m{})
+		require.NoError(t, err)
+		require.Empty(t, batches)
+	})
+}
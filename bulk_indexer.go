@@ -0,0 +1,214 @@
+package appsearch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBulkIndexerClosed Document was added to closed BulkIndexer
+var ErrBulkIndexerClosed = errors.New("bulk indexer is closed")
+
+// BulkIndexerConfig BulkIndexer configuration
+type BulkIndexerConfig struct {
+	// Engine to index documents into
+	EngineName string
+	// Number of concurrent workers (1 if not specified)
+	Workers int
+	// Number of documents in a single request (MaxBatchDocuments if not specified)
+	BatchSize int
+	// Interval of flushing incomplete batches (1 second if not specified)
+	FlushInterval time.Duration
+	// Called for every document which failed to index.
+	// Error is *Error with document errors or an error of the whole request.
+	// Called concurrently by workers.
+	OnFailure func(document interface{}, err error)
+}
+
+// BulkIndexerStats Statistics of BulkIndexer
+type BulkIndexerStats struct {
+	// Documents added
+	Added uint64
+	// Documents indexed successfully
+	Indexed uint64
+	// Documents failed to index
+	Failed uint64
+	// Requests sent
+	Requests uint64
+}
+
+// BulkIndexer Concurrently indexes documents in batches via UpdateDocuments.
+// Add blocks when all workers are busy, applying backpressure to the producer.
+type BulkIndexer struct {
+	// Updated atomically, first field to keep 64-bit alignment on 32-bit platforms
+	stats BulkIndexerStats
+
+	api    DocumentAPI
+	config BulkIndexerConfig
+
+	documents chan interface{}
+	closed    chan struct{}
+	closeOnce sync.Once
+	// Held for reading by Add while sending, so Close never closes documents during a send
+	sending sync.RWMutex
+	workers sync.WaitGroup
+
+	// Cancels requests in flight when Close deadline is exceeded
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Create BulkIndexer and start its workers
+func NewBulkIndexer(api DocumentAPI, config BulkIndexerConfig) *BulkIndexer {
+	if config.Workers < 1 {
+		config.Workers = 1
+	}
+	if config.BatchSize < 1 {
+		config.BatchSize = MaxBatchDocuments
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &BulkIndexer{
+		api:       api,
+		config:    config,
+		documents: make(chan interface{}, config.Workers*config.BatchSize),
+		closed:    make(chan struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	b.workers.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go b.work()
+	}
+
+	return b
+}
+
+// Add document to be indexed.
+// Blocks until document is accepted by a worker queue, ctx is done or BulkIndexer is closed.
+// Safe to call concurrently with Close.
+func (b *BulkIndexer) Add(ctx context.Context, document interface{}) error {
+	b.sending.RLock()
+	defer b.sending.RUnlock()
+
+	select {
+	case <-b.closed:
+		return ErrBulkIndexerClosed
+	default:
+	}
+
+	select {
+	case b.documents <- document:
+		atomic.AddUint64(&b.stats.Added, 1)
+		return nil
+	case <-b.closed:
+		return ErrBulkIndexerClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close BulkIndexer, wait for queued documents to be indexed and report stats.
+// Requests in flight are cancelled if ctx is done before workers finish.
+// Blocked Add calls return ErrBulkIndexerClosed.
+func (b *BulkIndexer) Close(ctx context.Context) (stats BulkIndexerStats, err error) {
+	b.closeOnce.Do(func() {
+		// Release blocked senders, then close queue when no send is in progress
+		close(b.closed)
+		b.sending.Lock()
+		close(b.documents)
+		b.sending.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		b.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		b.cancel()
+		<-done
+		err = ctx.Err()
+	}
+
+	b.cancel()
+	return b.Stats(), err
+}
+
+// Stats of BulkIndexer
+func (b *BulkIndexer) Stats() BulkIndexerStats {
+	return BulkIndexerStats{
+		Added:    atomic.LoadUint64(&b.stats.Added),
+		Indexed:  atomic.LoadUint64(&b.stats.Indexed),
+		Failed:   atomic.LoadUint64(&b.stats.Failed),
+		Requests: atomic.LoadUint64(&b.stats.Requests),
+	}
+}
+
+func (b *BulkIndexer) work() {
+	defer b.workers.Done()
+
+	ticker := time.NewTicker(b.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]interface{}, 0, b.config.BatchSize)
+
+	for {
+		select {
+		case document, ok := <-b.documents:
+			if !ok {
+				b.flush(batch)
+				return
+			}
+
+			batch = append(batch, document)
+			if len(batch) >= b.config.BatchSize {
+				b.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			b.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (b *BulkIndexer) flush(batch []interface{}) {
+	if len(batch) == 0 {
+		return
+	}
+
+	atomic.AddUint64(&b.stats.Requests, 1)
+	res, err := b.api.UpdateDocuments(b.ctx, b.config.EngineName, batch)
+
+	for i, document := range batch {
+		var documentErr error
+		switch {
+		case i < len(res) && len(res[i].Errors) > 0:
+			documentErr = &Error{Messages: res[i].Errors}
+		case i >= len(res) && err != nil:
+			documentErr = err
+		case i >= len(res):
+			documentErr = &Error{Message: "no response for document"}
+		}
+
+		if documentErr == nil {
+			atomic.AddUint64(&b.stats.Indexed, 1)
+			continue
+		}
+
+		atomic.AddUint64(&b.stats.Failed, 1)
+		if b.config.OnFailure != nil {
+			b.config.OnFailure(document, documentErr)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package appsearch
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkIndexer(t *testing.T) {
+	t.Run("Must index all documents in batches", func(t *testing.T) {
+		var mu sync.Mutex
+		var indexed []interface{}
+		var maxBatch int
+
+		api := stubDocumentAPI{update: func(ctx context.Context, engineName string, documents interface{}) ([]UpdateResponse, error) {
+			batch := documents.([]interface{})
+
+			mu.Lock()
+			indexed = append(indexed, batch...)
+			if len(batch) > maxBatch {
+				maxBatch = len(batch)
+			}
+			mu.Unlock()
+
+			return make([]UpdateResponse, len(batch)), nil
+		}}
+
+		indexer := NewBulkIndexer(api, BulkIndexerConfig{
+			EngineName: "engine",
+			Workers:    4,
+			BatchSize:  10,
+		})
+		for i := 0; i < 95; i++ {
+			require.NoError(t, indexer.Add(context.TODO(), m{"id": i}))
+		}
+
+		stats, err := indexer.Close(context.TODO())
+		require.NoError(t, err)
+		require.Len(t, indexed, 95)
+		require.LessOrEqual(t, maxBatch, 10)
+		require.EqualValues(t, 95, stats.Added)
+		require.EqualValues(t, 95, stats.Indexed)
+		require.EqualValues(t, 0, stats.Failed)
+		require.GreaterOrEqual(t, stats.Requests, uint64(10))
+
+		require.ErrorIs(t, indexer.Add(context.TODO(), m{"id": "late"}), ErrBulkIndexerClosed)
+	})
+
+	t.Run("Must report failed documents", func(t *testing.T) {
+		api := stubDocumentAPI{update: func(ctx context.Context, engineName string, documents interface{}) ([]UpdateResponse, error) {
+			batch := documents.([]interface{})
+			res := make([]UpdateResponse, len(batch))
+			for i, document := range batch {
+				if document.(m)["id"] == "bad" {
+					res[i].Errors = []string{"Invalid field name: none"}
+				}
+			}
+			return res, nil
+		}}
+
+		var mu sync.Mutex
+		failures := map[interface{}]error{}
+		indexer := NewBulkIndexer(api, BulkIndexerConfig{
+			OnFailure: func(document interface{}, err error) {
+				mu.Lock()
+				failures[document.(m)["id"]] = err
+				mu.Unlock()
+			},
+		})
+		require.NoError(t, indexer.Add(context.TODO(), m{"id": "good"}))
+		require.NoError(t, indexer.Add(context.TODO(), m{"id": "bad"}))
+
+		stats, err := indexer.Close(context.TODO())
+		require.NoError(t, err)
+		require.EqualValues(t, 1, stats.Indexed)
+		require.EqualValues(t, 1, stats.Failed)
+		require.Len(t, failures, 1)
+		require.EqualError(t, failures["bad"], "Invalid field name: none")
+	})
+
+	t.Run("Must flush incomplete batches on interval", func(t *testing.T) {
+		flushed := make(chan int, 1)
+		api := stubDocumentAPI{update: func(ctx context.Context, engineName string, documents interface{}) ([]UpdateResponse, error) {
+			batch := documents.([]interface{})
+			flushed <- len(batch)
+			return make([]UpdateResponse, len(batch)), nil
+		}}
+
+		indexer := NewBulkIndexer(api, BulkIndexerConfig{FlushInterval: 10 * time.Millisecond})
+		require.NoError(t, indexer.Add(context.TODO(), m{"id": "a"}))
+
+		select {
+		case n := <-flushed:
+			require.EqualValues(t, 1, n)
+		case <-time.After(time.Second):
+			t.Fatal("batch was not flushed")
+		}
+
+		_, err := indexer.Close(context.TODO())
+		require.NoError(t, err)
+	})
+
+	t.Run("Must cancel requests when Close deadline is exceeded", func(t *testing.T) {
+		api := stubDocumentAPI{update: func(ctx context.Context, engineName string, documents interface{}) ([]UpdateResponse, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}}
+
+		indexer := NewBulkIndexer(api, BulkIndexerConfig{})
+		require.NoError(t, indexer.Add(context.TODO(), m{"id": "a"}))
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		defer cancel()
+
+		stats, err := indexer.Close(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.EqualValues(t, 1, stats.Failed)
+	})
+	t.Run("Must reject Add blocked while closing", func(t *testing.T) {
+		release := make(chan struct{})
+		api := stubDocumentAPI{update: func(ctx context.Context, engineName string, documents interface{}) ([]UpdateResponse, error) {
+			<-release
+			return make([]UpdateResponse, len(documents.([]interface{}))), nil
+		}}
+
+		indexer := NewBulkIndexer(api, BulkIndexerConfig{EngineName: "engine", BatchSize: 1})
+
+		var accepted, rejected int64
+		var producers sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			producers.Add(1)
+			go func(i int) {
+				defer producers.Done()
+				switch err := indexer.Add(context.TODO(), m{"id": i}); err {
+				case nil:
+					atomic.AddInt64(&accepted, 1)
+				case ErrBulkIndexerClosed:
+					atomic.AddInt64(&rejected, 1)
+				}
+			}(i)
+		}
+
+		// Let producers block on full queue
+		time.Sleep(20 * time.Millisecond)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+		}()
+
+		stats, err := indexer.Close(context.TODO())
+		producers.Wait()
+		require.NoError(t, err)
+		require.Positive(t, rejected)
+		require.EqualValues(t, 20, accepted+rejected)
+		require.EqualValues(t, accepted, stats.Added)
+		require.EqualValues(t, accepted, stats.Indexed)
+	})
+}
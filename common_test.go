@@ -2,9 +2,19 @@ package appsearch
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
+	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
 )
 
 func createRandomEngine(c APIClient) EngineDescription {
@@ -31,3 +41,79 @@ func exit(err error) {
 		panic(err)
 	}
 }
+
+// DocumentAPI implemented by functions (unset methods panic)
+type stubDocumentAPI struct {
+	DocumentAPI
+	update func(ctx context.Context, engineName string, documents interface{}) ([]UpdateResponse, error)
+	search func(ctx context.Context, engineName string, query Query) (DocumentResponse, error)
+	list   func(ctx context.Context, engineName string, page Page) (DocumentResponse, error)
+}
+
+func (s stubDocumentAPI) UpdateDocuments(ctx context.Context, engineName string, documents interface{}) ([]UpdateResponse, error) {
+	return s.update(ctx, engineName, documents)
+}
+
+func (s stubDocumentAPI) SearchDocuments(ctx context.Context, engineName string, query Query) (DocumentResponse, error) {
+	return s.search(ctx, engineName, query)
+}
+
+func (s stubDocumentAPI) ListDocuments(ctx context.Context, engineName string, page Page) (DocumentResponse, error) {
+	return s.list(ctx, engineName, page)
+}
+
+// Page of documents with ID's from 0 to total
+func pageOfDocuments(total int, page Page) DocumentResponse {
+	response := DocumentResponse{Meta: ResponseMeta{Page: PaginationMeta{
+		PageSize:     page.Size,
+		CurrentPage:  page.Page,
+		TotalPages:   (total + page.Size - 1) / page.Size,
+		TotalResults: total,
+	}}}
+	for i := (page.Page - 1) * page.Size; i < page.Page*page.Size && i < total; i++ {
+		response.Results = append(response.Results, schema.Map{"id": strconv.Itoa(i)})
+	}
+	return response
+}
+
+// Request received by server of recordingClient (path is relative to API base path)
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// Client opened on server which records requests and responds with JSON of respond
+func recordingClient(t *testing.T, respond func(r recordedRequest) interface{}) (APIClient, func() []recordedRequest) {
+	var mu sync.Mutex
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		request := recordedRequest{
+			Method: r.Method,
+			Path:   strings.TrimPrefix(r.URL.Path, defaultBasePath),
+			Body:   string(body),
+		}
+
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(respond(request))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := OpenWithOptions(server.URL, WithRetry(RetryPolicy{}))
+	require.NoError(t, err)
+
+	return c, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
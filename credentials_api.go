@@ -0,0 +1,74 @@
+package appsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// List API keys with pagination
+func (c *client) ListCredentials(ctx context.Context, page Page) (data CredentialResponse, err error) {
+	err = c.Call(ctx, m{"page": page}, &data, http.MethodGet, "credentials")
+
+	return data, err
+}
+
+// List an API key by name
+func (c *client) ListCredential(ctx context.Context, keyName string) (data CredentialDescription, err error) {
+	err = c.Call(ctx, nil, &data, http.MethodGet, "credentials/%s", keyName)
+
+	return data, err
+}
+
+// Create API key
+func (c *client) CreateCredential(ctx context.Context, request CredentialRequest) (data CredentialDescription, err error) {
+	err = c.Call(ctx, request, &data, http.MethodPost, "credentials")
+	// API reports taken names of keys and engines with the same message
+	if errors.Is(err, ErrEngineAlreadyExists) {
+		err = ErrCredentialAlreadyExists
+	}
+
+	return data, err
+}
+
+// Update API key by name (key value is preserved)
+func (c *client) UpdateCredential(ctx context.Context, keyName string, request CredentialRequest) (data CredentialDescription, err error) {
+	err = c.Call(ctx, request, &data, http.MethodPut, "credentials/%s", keyName)
+
+	return data, err
+}
+
+// Rotate API key by name.
+// API doesn't support rotation in place, so key is deleted
+// and created again with the same name and permissions (and a new ID).
+// Rotation is not atomic: if key can't be created again (after a second attempt)
+// the deleted key description is returned with error so it can be recreated by caller.
+func (c *client) RotateCredential(ctx context.Context, keyName string) (data CredentialDescription, err error) {
+	current, err := c.ListCredential(ctx, keyName)
+	if err != nil {
+		return
+	}
+
+	err = c.DeleteCredential(ctx, keyName)
+	if err != nil {
+		return
+	}
+
+	request := current.Request()
+	if data, err = c.CreateCredential(ctx, request); err == nil {
+		return data, nil
+	}
+	if data, err = c.CreateCredential(ctx, request); err == nil {
+		return data, nil
+	}
+
+	return current, fmt.Errorf("%w: %s: %v", ErrCredentialNotRecreated, keyName, err)
+}
+
+// Delete API key by name
+func (c *client) DeleteCredential(ctx context.Context, keyName string) (err error) {
+	err = c.Call(ctx, nil, nil, http.MethodDelete, "credentials/%s", keyName)
+
+	return
+}
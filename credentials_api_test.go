@@ -0,0 +1,142 @@
+package appsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialsAPI(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	c, err := Open(os.Getenv("APPSEARCH"))
+	require.NoError(t, err)
+
+	t.Run("Must create, update and rotate credential", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		keyName := fmt.Sprintf("test-%d", rand.Uint64())
+		key, err := c.CreateCredential(ctx, CredentialRequest{
+			Name:    keyName,
+			Type:    SearchKey,
+			Engines: []string{engine.Name},
+		})
+		defer func() { _ = c.DeleteCredential(ctx, keyName) }()
+		require.NoError(t, err)
+		require.NotEmpty(t, key.Key)
+		require.EqualValues(t, []string{engine.Name}, key.Engines)
+
+		updated, err := c.UpdateCredential(ctx, keyName, CredentialRequest{
+			Name:             keyName,
+			Type:             SearchKey,
+			AccessAllEngines: true,
+		})
+		require.NoError(t, err)
+		require.True(t, updated.AccessAllEngines)
+		require.EqualValues(t, key.Key, updated.Key)
+
+		rotated, err := c.RotateCredential(ctx, keyName)
+		require.NoError(t, err)
+		require.NotEqual(t, key.Key, rotated.Key)
+		require.True(t, rotated.AccessAllEngines)
+	})
+
+	t.Run("Must delete credential", func(t *testing.T) {
+		t.Parallel()
+
+		keyName := fmt.Sprintf("test-%d", rand.Uint64())
+		_, err := c.CreateCredential(ctx, CredentialRequest{
+			Name:             keyName,
+			Type:             PrivateKey,
+			Read:             true,
+			AccessAllEngines: true,
+		})
+		require.NoError(t, err)
+
+		err = c.DeleteCredential(ctx, keyName)
+		require.NoError(t, err)
+
+		_, err = c.ListCredential(ctx, keyName)
+		require.ErrorIs(t, err, ErrCredentialDoesntExist)
+	})
+}
+
+func TestCredentialRequest(t *testing.T) {
+	t.Run("Must marshal only fields applicable to key type", func(t *testing.T) {
+		data, err := json.Marshal(CredentialRequest{
+			Name:             "admin",
+			Type:             AdminKey,
+			Read:             true,
+			AccessAllEngines: true,
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name": "admin", "type": "admin"}`, string(data))
+
+		data, err = json.Marshal(CredentialRequest{
+			Name:    "private",
+			Type:    PrivateKey,
+			Read:    true,
+			Engines: []string{"engine"},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"name": "private",
+			"type": "private",
+			"read": true,
+			"write": false,
+			"access_all_engines": false,
+			"engines": ["engine"]
+		}`, string(data))
+	})
+}
+
+func TestRotateCredential(t *testing.T) {
+	t.Run("Must return deleted key when it can't be created again", func(t *testing.T) {
+		var creates int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = w.Write([]byte(`{"id": "cred-1", "name": "search", "type": "search", "key": "search-1"}`))
+			case http.MethodDelete:
+				_, _ = w.Write([]byte(`{"deleted": true}`))
+			default:
+				creates++
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors": ["Invalid engine"]}`))
+			}
+		}))
+		defer server.Close()
+
+		c, err := OpenWithOptions(server.URL, WithBasePath("/"))
+		require.NoError(t, err)
+
+		deleted, err := c.RotateCredential(context.TODO(), "search")
+		require.ErrorIs(t, err, ErrCredentialNotRecreated)
+		require.EqualValues(t, 2, creates)
+		require.EqualValues(t, CredentialDescription{ID: "cred-1", Name: "search", Type: SearchKey, Key: "search-1"}, deleted)
+		require.EqualValues(t, CredentialRequest{Name: "search", Type: SearchKey}, deleted.Request())
+	})
+}
+
+func TestListCredentials(t *testing.T) {
+	c, requests := recordingClient(t, func(r recordedRequest) interface{} {
+		return CredentialResponse{}
+	})
+
+	_, err := c.ListCredentials(context.TODO(), Page{Page: 2, Size: 5})
+	require.NoError(t, err)
+	require.Len(t, requests(), 1)
+	require.Equal(t, "credentials", requests()[0].Path)
+	require.JSONEq(t, `{"page": {"current": 2, "size": 5}}`, requests()[0].Body)
+}
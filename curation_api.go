@@ -0,0 +1,53 @@
+package appsearch
+
+import (
+	"context"
+	"net/http"
+)
+
+// Curation ID returned by CreateCuration or UpdateCuration endpoints
+type createdCuration struct {
+	ID string `json:"id"`
+}
+
+// List curations with pagination
+func (c *client) ListCurations(ctx context.Context, engineName string, page Page) (data CurationResponse, err error) {
+	err = c.Call(ctx, m{"page": page}, &data, http.MethodGet, "engines/%s/curations", engineName)
+
+	return data, err
+}
+
+// List a curation by ID
+func (c *client) ListCuration(ctx context.Context, engineName string, curationID string) (data Curation, err error) {
+	err = c.Call(ctx, nil, &data, http.MethodGet, "engines/%s/curations/%s", engineName, curationID)
+
+	return data, err
+}
+
+// Create curation for queries with promoted and hidden documents
+func (c *client) CreateCuration(ctx context.Context, engineName string, request CurationRequest) (data Curation, err error) {
+	var created createdCuration
+	err = c.Call(ctx, request, &created, http.MethodPost, "engines/%s/curations", engineName)
+	if err != nil {
+		return
+	}
+
+	return request.Curation(created.ID), nil
+}
+
+// Update (replace) curation by ID
+func (c *client) UpdateCuration(ctx context.Context, engineName string, curationID string, request CurationRequest) (data Curation, err error) {
+	err = c.Call(ctx, request, nil, http.MethodPut, "engines/%s/curations/%s", engineName, curationID)
+	if err != nil {
+		return
+	}
+
+	return request.Curation(curationID), nil
+}
+
+// Delete curation by ID
+func (c *client) DeleteCuration(ctx context.Context, engineName string, curationID string) (err error) {
+	err = c.Call(ctx, nil, nil, http.MethodDelete, "engines/%s/curations/%s", engineName, curationID)
+
+	return
+}
@@ -0,0 +1,104 @@
+package appsearch
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurationAPI(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	c, err := Open(os.Getenv("APPSEARCH"))
+	require.NoError(t, err)
+
+	createCuratedEngine := func() EngineDescription {
+		engine := createRandomEngine(c)
+		_, err := c.UpdateDocuments(ctx, engine.Name, []m{
+			{"id": "promoted-park", "title": "Promoted park"},
+			{"id": "hidden-park", "title": "Hidden park"},
+		})
+		exit(err)
+		return engine
+	}
+
+	t.Run("Must create and list curation", func(t *testing.T) {
+		t.Parallel()
+		engine := createCuratedEngine()
+		defer deleteEngine(c, engine)
+
+		curation, err := c.CreateCuration(ctx, engine.Name, CurationRequest{
+			Queries:  []string{"park"},
+			Promoted: []string{"promoted-park"},
+			Hidden:   []string{"hidden-park"},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, curation.ID)
+
+		listed, err := c.ListCuration(ctx, engine.Name, curation.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, curation.Queries, listed.Queries)
+		require.EqualValues(t, curation.Promoted, listed.Promoted)
+		require.EqualValues(t, curation.Hidden, listed.Hidden)
+
+		curations, err := c.ListCurations(ctx, engine.Name, Page{1, 25})
+		require.NoError(t, err)
+		require.Len(t, curations.Results, 1)
+	})
+
+	t.Run("Must update curation", func(t *testing.T) {
+		t.Parallel()
+		engine := createCuratedEngine()
+		defer deleteEngine(c, engine)
+
+		curation, err := c.CreateCuration(ctx, engine.Name, CurationRequest{
+			Queries:  []string{"park"},
+			Promoted: []string{"promoted-park"},
+		})
+		require.NoError(t, err)
+
+		_, err = c.UpdateCuration(ctx, engine.Name, curation.ID, CurationRequest{
+			Queries: []string{"park"},
+			Hidden:  []string{"hidden-park"},
+		})
+		require.NoError(t, err)
+
+		listed, err := c.ListCuration(ctx, engine.Name, curation.ID)
+		require.NoError(t, err)
+		require.Empty(t, listed.Promoted)
+		require.EqualValues(t, []string{"hidden-park"}, listed.Hidden)
+	})
+
+	t.Run("Must delete curation", func(t *testing.T) {
+		t.Parallel()
+		engine := createCuratedEngine()
+		defer deleteEngine(c, engine)
+
+		curation, err := c.CreateCuration(ctx, engine.Name, CurationRequest{
+			Queries:  []string{"park"},
+			Promoted: []string{"promoted-park"},
+		})
+		require.NoError(t, err)
+
+		err = c.DeleteCuration(ctx, engine.Name, curation.ID)
+		require.NoError(t, err)
+
+		_, err = c.ListCuration(ctx, engine.Name, curation.ID)
+		require.ErrorIs(t, err, ErrCurationDoesntExist)
+	})
+}
+
+func TestListCurations(t *testing.T) {
+	c, requests := recordingClient(t, func(r recordedRequest) interface{} {
+		return CurationResponse{}
+	})
+
+	_, err := c.ListCurations(context.TODO(), "engine", Page{Page: 2, Size: 5})
+	require.NoError(t, err)
+	require.Len(t, requests(), 1)
+	require.Equal(t, "engines/engine/curations", requests()[0].Path)
+	require.JSONEq(t, `{"page": {"current": 2, "size": 5}}`, requests()[0].Body)
+}
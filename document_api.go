@@ -3,34 +3,72 @@ package appsearch
 import (
 	"context"
 	"net/http"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
 )
 
 // Patch a list of documents. Every document must contain "id".
 // Every document is patched separately.
 // Documents without ID will be rejected.
 // Non-existing documents will be rejected.
+// Documents are sent in batches within API limits.
 func (c *client) PatchDocuments(ctx context.Context, engineName string, documents interface{}) (res []UpdateResponse, err error) {
-	err = c.Call(ctx, documents, &res, http.MethodPatch, "engines/%s/documents", engineName)
-
-	return res, err
+	return writeDocuments[UpdateResponse](ctx, c, http.MethodPatch, engineName, documents)
 }
 
 // Update (replace) a list of documents
 // Every document is created (or replaced) separately.
 // Documents without ID will have auto-generated ID's.
 // Non-existing documents will be automatically created.
+// Documents are sent in batches within API limits.
 func (c *client) UpdateDocuments(ctx context.Context, engineName string, documents interface{}) (res []UpdateResponse, err error) {
-	err = c.Call(ctx, documents, &res, http.MethodPost, "engines/%s/documents", engineName)
-
-	return res, err
+	return writeDocuments[UpdateResponse](ctx, c, http.MethodPost, engineName, documents)
 }
 
 // Remove a list of documents specified as string ID's or documents with "id" field
 // Every document is deleted separately.
+// Documents are sent in batches within API limits.
 func (c *client) RemoveDocuments(ctx context.Context, engineName string, documents interface{}) (res []DeleteResponse, err error) {
-	err = c.Call(ctx, documents, &res, http.MethodDelete, "engines/%s/documents", engineName)
+	return writeDocuments[DeleteResponse](ctx, c, http.MethodDelete, engineName, documents)
+}
+
+// Send documents in batches and merge responses in order of documents.
+// Responses of batches sent before failure are returned along with error.
+func writeDocuments[T any](ctx context.Context, c *client, method, engineName string, documents interface{}) (res []T, err error) {
+	batches, err := splitBatches(documents)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, batch := range batches {
+		var batchRes []T
+		err = c.Call(ctx, batch, &batchRes, method, "engines/%s/documents", engineName)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, batchRes...)
+	}
+
+	return res, nil
+}
+
+// Get documents by ID's.
+// Documents are returned in order of ID's with nil for missing documents.
+func (c *client) GetDocuments(ctx context.Context, engineName string, ids []string) (documents []schema.Map, err error) {
+	err = c.Call(ctx, ids, &documents, http.MethodGet, "engines/%s/documents", engineName)
+
+	return documents, err
+}
+
+// Get documents by ID's and unpack them into output slice via schema.UnpackSlice.
+// Missing documents are unpacked as zero values.
+func GetDocumentsInto(ctx context.Context, api DocumentAPI, engineName string, ids []string, output interface{}) (err error) {
+	documents, err := api.GetDocuments(ctx, engineName, ids)
+	if err != nil {
+		return err
+	}
 
-	return res, err
+	return schema.UnpackSlice(documents, output)
 }
 
 // List documents
@@ -46,3 +84,42 @@ func (c *client) SearchDocuments(ctx context.Context, engineName string, query Q
 
 	return response, err
 }
+
+// Suggest queries for partial query
+func (c *client) QuerySuggestion(ctx context.Context, engineName string, request SuggestionRequest) (response SuggestionResponse, err error) {
+	err = c.Call(ctx, request, &response, http.MethodPost, "engines/%s/query_suggestion", engineName)
+
+	return response, err
+}
+
+// Single response of multi_search endpoint
+type multiSearchResponse struct {
+	DocumentResponse
+	Errors []string `json:"errors"`
+}
+
+// Search documents by multiple queries in one request.
+// Responses are returned in order of queries.
+// Failed queries are reported as *MultiSearchError.
+func (c *client) MultiSearchDocuments(ctx context.Context, engineName string, queries []Query) (responses []DocumentResponse, err error) {
+	var results []multiSearchResponse
+	err = c.Call(ctx, m{"queries": queries}, &results, http.MethodPost, "engines/%s/multi_search", engineName)
+	if err != nil {
+		return nil, err
+	}
+
+	responses = make([]DocumentResponse, len(results))
+	failed := make(map[int]error)
+	for i, result := range results {
+		responses[i] = result.DocumentResponse
+		if len(result.Errors) > 0 {
+			failed[i] = &Error{Messages: result.Errors}
+		}
+	}
+
+	if len(failed) > 0 {
+		return responses, &MultiSearchError{Errors: failed}
+	}
+
+	return responses, nil
+}
@@ -3,11 +3,15 @@ package appsearch
 import (
 	"context"
 	"math/rand"
+	"net/http"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/filter"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
 )
 
 func TestDocumentAPI(t *testing.T) {
@@ -158,4 +162,110 @@ func TestDocumentAPI(t *testing.T) {
 			require.NotEmpty(t, facet.Count)
 		}
 	})
+	t.Run("Must multi search documents", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		_, err := c.UpdateDocuments(ctx, engine.Name, []m{
+			{"id": "national-parks", "title": "Amazing title"},
+			{"id": "state-parks", "title": "Boring title"},
+		})
+		require.NoError(t, err)
+
+		time.Sleep(time.Second)
+
+		responses, err := c.MultiSearchDocuments(ctx, engine.Name, []Query{
+			{Query: "amazing"},
+			{Query: "boring"},
+		})
+		require.NoError(t, err)
+		require.Len(t, responses, 2)
+		require.Len(t, responses[0].Results, 1)
+		require.Len(t, responses[1].Results, 1)
+	})
+	t.Run("Must suggest queries", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		_, err := c.UpdateDocuments(ctx, engine.Name, []m{
+			{"id": "national-parks", "title": "Amazing title"},
+		})
+		require.NoError(t, err)
+
+		time.Sleep(time.Second)
+
+		response, err := c.QuerySuggestion(ctx, engine.Name, SuggestionRequest{
+			Query: "ama",
+			Types: &SuggestionTypes{
+				Documents: &SuggestionDocuments{Fields: []string{"title"}},
+			},
+			Size: 5,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, response.Results.Documents)
+	})
+	t.Run("Must get documents by ID", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		_, err := c.UpdateDocuments(ctx, engine.Name, []m{
+			{"id": "national-parks", "title": "Amazing title"},
+		})
+		require.NoError(t, err)
+
+		documents, err := c.GetDocuments(ctx, engine.Name, []string{"national-parks", "missing"})
+		require.NoError(t, err)
+		require.Len(t, documents, 2)
+		require.EqualValues(t, "Amazing title", documents[0]["title"])
+		require.Nil(t, documents[1])
+
+		type model struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		}
+		var results []model
+		err = GetDocumentsInto(ctx, c, engine.Name, []string{"national-parks", "missing"}, &results)
+		require.NoError(t, err)
+		require.EqualValues(t, []model{
+			{ID: "national-parks", Title: "Amazing title"},
+			{},
+		}, results)
+	})
+	t.Run("Must filter documents with filter tree", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		_, err := c.UpdateDocuments(ctx, engine.Name, []m{
+			{"id": "utah-park", "state": "Utah"},
+			{"id": "kansas-park", "state": "Kansas"},
+		})
+		require.NoError(t, err)
+
+		time.Sleep(time.Second)
+
+		response, err := c.SearchDocuments(ctx, engine.Name, Query{
+			Filters: filter.Not{filter.Value{Field: "state", Values: []interface{}{"Kansas"}}}.Map(),
+		})
+		require.NoError(t, err)
+		require.Len(t, response.Results, 1)
+	})
+}
+
+func TestGetDocuments(t *testing.T) {
+	c, requests := recordingClient(t, func(r recordedRequest) interface{} {
+		return []interface{}{m{"id": "national-parks"}, nil}
+	})
+
+	documents, err := c.GetDocuments(context.TODO(), "engine", []string{"national-parks", "missing"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Map{{"id": "national-parks"}, nil}, documents)
+	require.Equal(t, []recordedRequest{{
+		Method: http.MethodGet,
+		Path:   "engines/engine/documents",
+		Body:   `["national-parks","missing"]`,
+	}}, requests())
 }
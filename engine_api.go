@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/lithiumlabcompany/appsearch/internal/pkg/converge"
 	"github.com/lithiumlabcompany/appsearch/pkg/schema"
 )
 
@@ -30,7 +31,7 @@ func (c *client) ListAllEngines(ctx context.Context) (engines []EngineDescriptio
 
 // List engines with pagination
 func (c *client) ListEngines(ctx context.Context, page Page) (data EngineResponse, err error) {
-	err = c.Call(ctx, page, &data, http.MethodGet, "engines")
+	err = c.Call(ctx, m{"page": page}, &data, http.MethodGet, "engines")
 
 	return data, err
 }
@@ -49,6 +50,29 @@ func (c *client) CreateEngine(ctx context.Context, request CreateEngineRequest)
 	return
 }
 
+// Create a meta engine from a list of source engines
+func (c *client) CreateMetaEngine(ctx context.Context, engineName string, sourceEngines []string) (resp EngineDescription, err error) {
+	return c.CreateEngine(ctx, CreateEngineRequest{
+		Name:          engineName,
+		Type:          MetaEngine,
+		SourceEngines: sourceEngines,
+	})
+}
+
+// Add source engines to a meta engine
+func (c *client) AddSourceEngines(ctx context.Context, engineName string, sourceEngines []string) (resp EngineDescription, err error) {
+	err = c.Call(ctx, sourceEngines, &resp, http.MethodPost, "engines/%s/source_engines", engineName)
+
+	return
+}
+
+// Remove source engines from a meta engine
+func (c *client) RemoveSourceEngines(ctx context.Context, engineName string, sourceEngines []string) (resp EngineDescription, err error) {
+	err = c.Call(ctx, sourceEngines, &resp, http.MethodDelete, "engines/%s/source_engines", engineName)
+
+	return
+}
+
 // Delete engine by name
 func (c *client) DeleteEngine(ctx context.Context, engineName string) (err error) {
 	err = c.Call(ctx, nil, nil, http.MethodDelete, "engines/%s", engineName)
@@ -57,12 +81,15 @@ func (c *client) DeleteEngine(ctx context.Context, engineName string) (err error
 }
 
 // Create engine if doesn't exist.
+// Source engines of existing meta engine are added or removed to match request.
 // Optionally update a schema even if engine exists.
 func (c *client) EnsureEngine(ctx context.Context, request CreateEngineRequest, schema ...schema.Definition) (err error) {
-	_, err = c.ListEngine(ctx, request.Name)
+	engine, err := c.ListEngine(ctx, request.Name)
 
 	if errors.Is(err, ErrEngineDoesntExist) {
 		_, err = c.CreateEngine(ctx, request)
+	} else if err == nil && request.Type == MetaEngine {
+		err = c.ensureSourceEngines(ctx, engine, request.SourceEngines)
 	}
 
 	if err == nil && len(schema) > 0 {
@@ -71,3 +98,14 @@ func (c *client) EnsureEngine(ctx context.Context, request CreateEngineRequest,
 
 	return err
 }
+
+// Add missing and remove extra source engines of meta engine to match sourceEngines
+func (c *client) ensureSourceEngines(ctx context.Context, engine EngineDescription, sourceEngines []string) error {
+	return converge.Set(engine.SourceEngines, sourceEngines, func(missing []string) error {
+		_, err := c.AddSourceEngines(ctx, engine.Name, missing)
+		return err
+	}, func(extra []string) error {
+		_, err := c.RemoveSourceEngines(ctx, engine.Name, extra)
+		return err
+	})
+}
@@ -99,4 +99,65 @@ func TestEngineAPI(t *testing.T) {
 			require.EqualValues(t, schema, def)
 		})
 	})
+	t.Run("MetaEngine", func(t *testing.T) {
+		t.Parallel()
+		t.Run("Must create meta engine from source engines", func(t *testing.T) {
+			t.Parallel()
+			source := createRandomEngine(c)
+			defer deleteEngine(c, source)
+
+			engineName := fmt.Sprintf("test-%d", rand.Uint64())
+			engine, err := c.CreateMetaEngine(ctx, engineName, []string{source.Name})
+			defer deleteEngine(c, engineName)
+
+			require.NoError(t, err)
+			require.EqualValues(t, MetaEngine, engine.Type)
+			require.EqualValues(t, []string{source.Name}, engine.SourceEngines)
+		})
+
+		t.Run("Must add and remove source engines", func(t *testing.T) {
+			t.Parallel()
+			first := createRandomEngine(c)
+			defer deleteEngine(c, first)
+			second := createRandomEngine(c)
+			defer deleteEngine(c, second)
+
+			engineName := fmt.Sprintf("test-%d", rand.Uint64())
+			_, err := c.CreateMetaEngine(ctx, engineName, []string{first.Name})
+			defer deleteEngine(c, engineName)
+			require.NoError(t, err)
+
+			engine, err := c.AddSourceEngines(ctx, engineName, []string{second.Name})
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{first.Name, second.Name}, engine.SourceEngines)
+
+			engine, err = c.RemoveSourceEngines(ctx, engineName, []string{first.Name})
+			require.NoError(t, err)
+			require.EqualValues(t, []string{second.Name}, engine.SourceEngines)
+		})
+
+		t.Run("EnsureEngine must converge source engines", func(t *testing.T) {
+			t.Parallel()
+			first := createRandomEngine(c)
+			defer deleteEngine(c, first)
+			second := createRandomEngine(c)
+			defer deleteEngine(c, second)
+
+			engineName := fmt.Sprintf("test-%d", rand.Uint64())
+			_, err := c.CreateMetaEngine(ctx, engineName, []string{first.Name})
+			defer deleteEngine(c, engineName)
+			require.NoError(t, err)
+
+			err = c.EnsureEngine(ctx, CreateEngineRequest{
+				Name:          engineName,
+				Type:          MetaEngine,
+				SourceEngines: []string{second.Name},
+			})
+			require.NoError(t, err)
+
+			engine, err := c.ListEngine(ctx, engineName)
+			require.NoError(t, err)
+			require.EqualValues(t, []string{second.Name}, engine.SourceEngines)
+		})
+	})
 }
@@ -6,14 +6,31 @@ import (
 
 var (
 	// ErrInvalidParams Invalid params specified for appsearch.Open
-	ErrInvalidParams       = errors.New("invalid params specified for Open(): accepted are (endpoint, [key])")
+	ErrInvalidParams = errors.New("invalid params specified for Open(): accepted are (endpoint, [key])")
+	// ErrInvalidOption Option of OpenWithOptions can't be applied
+	ErrInvalidOption = errors.New("invalid option specified for OpenWithOptions()")
 	// ErrEngineDoesntExist Engine you want to create already exists
-	ErrEngineDoesntExist   = errors.New("engine doesn't exist")
+	ErrEngineDoesntExist = errors.New("engine doesn't exist")
 	// ErrEngineAlreadyExists Engine you're listing doesn't exist
 	ErrEngineAlreadyExists = errors.New("engine already exists")
+	// ErrSynonymSetDoesntExist Synonym set you're listing doesn't exist
+	ErrSynonymSetDoesntExist = errors.New("synonym set doesn't exist")
+	// ErrCurationDoesntExist Curation you're listing doesn't exist
+	ErrCurationDoesntExist = errors.New("curation doesn't exist")
+	// ErrCredentialDoesntExist API key you're listing doesn't exist
+	ErrCredentialDoesntExist = errors.New("credential doesn't exist")
+	// ErrCredentialAlreadyExists API key you're creating already exists
+	ErrCredentialAlreadyExists = errors.New("credential already exists")
+	// ErrCredentialNotRecreated API key was deleted by RotateCredential but couldn't be created again
+	ErrCredentialNotRecreated = errors.New("credential was deleted but not recreated")
+	// ErrRateLimited Request was rejected by rate limit of API (matches *Error with status 429)
+	ErrRateLimited = errors.New("rate limited")
 )
 
 var apiErrors = map[string]error{
 	"Name is already taken":  ErrEngineAlreadyExists,
 	"Could not find engine.": ErrEngineDoesntExist,
+	"Synonym set not found.": ErrSynonymSetDoesntExist,
+	"Curation not found.":    ErrCurationDoesntExist,
+	"Credential not found.":  ErrCredentialDoesntExist,
 }
@@ -1,12 +1,18 @@
 module github.com/lithiumlabcompany/appsearch
 
-go 1.14
+go 1.18
 
 require (
 	github.com/go-resty/resty/v2 v2.5.0
 	github.com/google/uuid v1.2.0
-	github.com/rs/zerolog v1.21.0
 	github.com/stretchr/testify v1.7.0
 	go.mongodb.org/mongo-driver v1.5.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-stack/stack v1.8.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/net v0.0.0-20210324205630-d1beb07c2056 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
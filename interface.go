@@ -12,19 +12,31 @@ type DocumentAPI interface {
 	// Every document is processed separately.
 	// Documents without ID will be rejected.
 	// Non-existent documents will be rejected.
+	// Documents are split into batches of MaxBatchDocuments and MaxBatchSize.
 	PatchDocuments(ctx context.Context, engineName string, documents interface{}) (res []UpdateResponse, err error)
 	// Update (replace) a list of documents
 	// Every document is processed separately.
 	// Documents without ID will have auto-generated ID's.
 	// Non-existent documents will be automatically created.
+	// Documents are split into batches of MaxBatchDocuments and MaxBatchSize.
 	UpdateDocuments(ctx context.Context, engineName string, documents interface{}) (res []UpdateResponse, err error)
 	// Remove a list of documents specified as []string of ID's or []interface{} of documents with "id" field
 	// Every document is processed separately.
+	// Documents are split into batches of MaxBatchDocuments and MaxBatchSize.
 	RemoveDocuments(ctx context.Context, engineName string, documentsOrIDs interface{}) (res []DeleteResponse, err error)
+	// Get documents by ID's.
+	// Documents are returned in order of ID's with nil for missing documents.
+	GetDocuments(ctx context.Context, engineName string, ids []string) (documents []schema.Map, err error)
 	// List documents
 	ListDocuments(ctx context.Context, engineName string, page Page) (response DocumentResponse, err error)
 	// Search documents by query
 	SearchDocuments(ctx context.Context, engineName string, query Query) (response DocumentResponse, err error)
+	// Search documents by multiple queries in one request.
+	// Responses are returned in order of queries.
+	// Failed queries are reported as *MultiSearchError.
+	MultiSearchDocuments(ctx context.Context, engineName string, queries []Query) (responses []DocumentResponse, err error)
+	// Suggest queries for partial query
+	QuerySuggestion(ctx context.Context, engineName string, request SuggestionRequest) (response SuggestionResponse, err error)
 }
 
 // EngineAPI engine api
@@ -38,10 +50,17 @@ type EngineAPI interface {
 
 	// Create engine with name
 	CreateEngine(ctx context.Context, request CreateEngineRequest) (EngineDescription, error)
+	// Create meta engine with name from a list of source engines
+	CreateMetaEngine(ctx context.Context, engineName string, sourceEngines []string) (EngineDescription, error)
+	// Add source engines to meta engine
+	AddSourceEngines(ctx context.Context, engineName string, sourceEngines []string) (EngineDescription, error)
+	// Remove source engines from meta engine
+	RemoveSourceEngines(ctx context.Context, engineName string, sourceEngines []string) (EngineDescription, error)
 	// Delete engine with name
 	DeleteEngine(ctx context.Context, engineName string) (err error)
 
 	// Create engine if doesn't exist.
+	// Source engines of existing meta engine are added or removed to match request.
 	// Optionally update a schema even if engine exists.
 	EnsureEngine(ctx context.Context, request CreateEngineRequest, schema ...schema.Definition) (err error)
 }
@@ -54,6 +73,85 @@ type SchemaAPI interface {
 	// Update schema by engineName (create or change fields).
 	// Fields cannot be deleted.
 	UpdateSchema(ctx context.Context, engineName string, def schema.Definition) (err error)
+
+	// Plan migration of engine schema to desired definition.
+	// App Search silently refuses type changes and deletions of fields, these require reindex.
+	PlanSchemaMigration(ctx context.Context, engineName string, desired schema.Definition) (plan SchemaMigrationPlan, err error)
+}
+
+// SynonymAPI synonym api
+type SynonymAPI interface {
+	// List synonym sets with pagination
+	ListSynonymSets(ctx context.Context, engineName string, page Page) (data SynonymSetResponse, err error)
+	// List a synonym set by ID
+	ListSynonymSet(ctx context.Context, engineName string, synonymSetID string) (data SynonymSet, err error)
+
+	// Create synonym set
+	CreateSynonymSet(ctx context.Context, engineName string, request SynonymSetRequest) (data SynonymSet, err error)
+	// Update (replace) synonyms of synonym set by ID
+	UpdateSynonymSet(ctx context.Context, engineName string, synonymSetID string, request SynonymSetRequest) (data SynonymSet, err error)
+	// Delete synonym set by ID
+	DeleteSynonymSet(ctx context.Context, engineName string, synonymSetID string) (err error)
+}
+
+// CurationAPI curation api
+type CurationAPI interface {
+	// List curations with pagination
+	ListCurations(ctx context.Context, engineName string, page Page) (data CurationResponse, err error)
+	// List a curation by ID
+	ListCuration(ctx context.Context, engineName string, curationID string) (data Curation, err error)
+
+	// Create curation for queries with promoted and hidden documents
+	CreateCuration(ctx context.Context, engineName string, request CurationRequest) (data Curation, err error)
+	// Update (replace) curation by ID
+	UpdateCuration(ctx context.Context, engineName string, curationID string, request CurationRequest) (data Curation, err error)
+	// Delete curation by ID
+	DeleteCuration(ctx context.Context, engineName string, curationID string) (err error)
+}
+
+// SearchSettingsAPI search settings api
+type SearchSettingsAPI interface {
+	// List search settings of engine
+	ListSearchSettings(ctx context.Context, engineName string) (data SearchSettings, err error)
+	// Update (replace) search settings of engine
+	UpdateSearchSettings(ctx context.Context, engineName string, settings SearchSettings) (data SearchSettings, err error)
+	// Reset search settings of engine to defaults
+	ResetSearchSettings(ctx context.Context, engineName string) (data SearchSettings, err error)
+
+	// Update search settings only if they differ from current.
+	// Precision is preserved if not specified.
+	EnsureSearchSettings(ctx context.Context, engineName string, settings SearchSettings) (err error)
+}
+
+// AnalyticsAPI analytics api
+type AnalyticsAPI interface {
+	// Log click on a document returned by search
+	LogClickthrough(ctx context.Context, engineName string, request ClickRequest) (err error)
+
+	// Number of queries and clicks by interval
+	AnalyticsCounts(ctx context.Context, engineName string, request AnalyticsCountsRequest) (data AnalyticsCountsResponse, err error)
+	// Top queries filtered by date, tags, presence of results or clicks
+	TopQueries(ctx context.Context, engineName string, request TopQueriesRequest) (data TopQueriesResponse, err error)
+	// Top clicked documents, optionally for a single query
+	TopClicks(ctx context.Context, engineName string, request TopClicksRequest) (data TopClicksResponse, err error)
+}
+
+// CredentialsAPI credentials api
+type CredentialsAPI interface {
+	// List API keys with pagination
+	ListCredentials(ctx context.Context, page Page) (data CredentialResponse, err error)
+	// List an API key by name
+	ListCredential(ctx context.Context, keyName string) (data CredentialDescription, err error)
+
+	// Create API key
+	CreateCredential(ctx context.Context, request CredentialRequest) (data CredentialDescription, err error)
+	// Update API key by name (key value is preserved)
+	UpdateCredential(ctx context.Context, keyName string, request CredentialRequest) (data CredentialDescription, err error)
+	// Rotate API key by name (key is recreated with the same name and permissions).
+	// Not atomic: on ErrCredentialNotRecreated returned description is of the deleted key.
+	RotateCredential(ctx context.Context, keyName string) (data CredentialDescription, err error)
+	// Delete API key by name
+	DeleteCredential(ctx context.Context, keyName string) (err error)
 }
 
 // APIClient interface
@@ -64,4 +162,14 @@ type APIClient interface {
 	SchemaAPI
 	// Document API
 	DocumentAPI
+	// Synonym API
+	SynonymAPI
+	// Curation API
+	CurationAPI
+	// Search Settings API
+	SearchSettingsAPI
+	// Analytics API
+	AnalyticsAPI
+	// Credentials API
+	CredentialsAPI
 }
@@ -0,0 +1,40 @@
+// Package converge changes current state of resources to desired state with as few updates as possible
+package converge
+
+// Set Add values of desired missing in current, then remove values of current missing in desired
+func Set(current, desired []string, add, remove func(values []string) error) (err error) {
+	if missing := Difference(desired, current); len(missing) > 0 {
+		err = add(missing)
+	}
+
+	if extra := Difference(current, desired); err == nil && len(extra) > 0 {
+		err = remove(extra)
+	}
+
+	return err
+}
+
+// Value Update current value to desired value only if they differ
+func Value[T interface{ Equal(T) bool }](current, desired T, update func(value T) error) error {
+	if desired.Equal(current) {
+		return nil
+	}
+
+	return update(desired)
+}
+
+// Difference Values of a not present in b (in order of a)
+func Difference(a, b []string) (diff []string) {
+	present := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		present[v] = struct{}{}
+	}
+
+	for _, v := range a {
+		if _, ok := present[v]; !ok {
+			diff = append(diff, v)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,52 @@
+package converge
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type number int
+
+func (n number) Equal(other number) bool {
+	return n == other
+}
+
+func TestConverge(t *testing.T) {
+	t.Run("Must add missing and remove extra values", func(t *testing.T) {
+		var added, removed []string
+		err := Set([]string{"a", "b"}, []string{"b", "c", "d"}, func(values []string) error {
+			added = values
+			return nil
+		}, func(values []string) error {
+			removed = values
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"c", "d"}, added)
+		require.Equal(t, []string{"a"}, removed)
+	})
+
+	t.Run("Must not remove values when adding fails", func(t *testing.T) {
+		failure := errors.New("failure")
+		err := Set([]string{"a"}, []string{"b"}, func(values []string) error {
+			return failure
+		}, func(values []string) error {
+			t.Fatal("removed after failure")
+			return nil
+		})
+		require.ErrorIs(t, err, failure)
+	})
+
+	t.Run("Must update value only if it differs", func(t *testing.T) {
+		var updates []number
+		update := func(value number) error {
+			updates = append(updates, value)
+			return nil
+		}
+		require.NoError(t, Value(number(1), number(1), update))
+		require.NoError(t, Value(number(1), number(2), update))
+		require.Equal(t, []number{2}, updates)
+	})
+}
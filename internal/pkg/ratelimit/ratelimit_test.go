@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Run("Must allow burst and delay the rest", func(t *testing.T) {
+		now := time.Now()
+		l := New(10, 2)
+		l.now = func() time.Time { return now }
+
+		require.Zero(t, l.reserve())
+		require.Zero(t, l.reserve())
+		require.Equal(t, 100*time.Millisecond, l.reserve())
+		require.Equal(t, 200*time.Millisecond, l.reserve())
+
+		// Refilled
+		now = now.Add(time.Second)
+		require.Zero(t, l.reserve())
+	})
+
+	t.Run("Must limit concurrent callers", func(t *testing.T) {
+		l := New(100, 1)
+		start := time.Now()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = l.Wait(context.TODO())
+			}()
+		}
+		wg.Wait()
+
+		require.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+	})
+
+	t.Run("Must return token when context is done", func(t *testing.T) {
+		now := time.Now()
+		l := New(1, 1)
+		l.now = func() time.Time { return now }
+		require.NoError(t, l.Wait(context.TODO()))
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		require.ErrorIs(t, l.Wait(ctx), context.Canceled)
+		require.Equal(t, time.Second, l.reserve())
+	})
+
+	t.Run("Must not limit without rate", func(t *testing.T) {
+		var l *Limiter = New(0, 0)
+		require.Nil(t, l)
+		require.NoError(t, l.Wait(context.TODO()))
+	})
+}
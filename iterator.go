@@ -0,0 +1,297 @@
+package appsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/filter"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+// DocumentIterator Lazy iterator over documents of paged responses
+type DocumentIterator interface {
+	// Advance to next document fetching next page when needed.
+	// Returns false when documents are exhausted, iteration failed or context is done.
+	Next() bool
+	// Current document
+	Doc() schema.Map
+	// Error which stopped iteration
+	Err() error
+}
+
+// Fetch a page of documents
+type pageFetcher func(ctx context.Context, page int) (DocumentResponse, error)
+
+type pageIterator struct {
+	ctx   context.Context
+	fetch pageFetcher
+
+	page       int
+	totalPages int
+	documents  []schema.Map
+	index      int
+
+	doc schema.Map
+	err error
+}
+
+// Iterate over all documents of engine fetching pages of pageSize lazily
+func IterateDocuments(ctx context.Context, api DocumentAPI, engineName string, pageSize int) DocumentIterator {
+	return &pageIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, page int) (DocumentResponse, error) {
+			return api.ListDocuments(ctx, engineName, Page{Page: page, Size: pageSize})
+		},
+	}
+}
+
+// Iterate over all results of search query fetching pages lazily.
+// Page size of query is preserved, page number is ignored.
+// Search is limited to 100 pages by API.
+func IterateSearch(ctx context.Context, api DocumentAPI, engineName string, query Query) DocumentIterator {
+	pageSize := 0
+	if query.Page != nil {
+		pageSize = query.Page.Size
+	}
+
+	return &pageIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, page int) (DocumentResponse, error) {
+			query.Page = &Page{Page: page, Size: pageSize}
+			return api.SearchDocuments(ctx, engineName, query)
+		},
+	}
+}
+
+func (it *pageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.documents) {
+		// First page is always fetched, then until total pages
+		if it.page > 0 && it.page >= it.totalPages {
+			return false
+		}
+
+		if it.err = it.ctx.Err(); it.err != nil {
+			return false
+		}
+
+		it.page++
+		response, err := it.fetch(it.ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.totalPages = response.Meta.Page.TotalPages
+		it.documents = response.Results
+		it.index = 0
+
+		if len(it.documents) == 0 {
+			return false
+		}
+	}
+
+	it.doc = it.documents[it.index]
+	it.index++
+	return true
+}
+
+func (it *pageIterator) Doc() schema.Map {
+	return it.doc
+}
+
+func (it *pageIterator) Err() error {
+	return it.err
+}
+
+const (
+	// DefaultPageSize Page size of search when none is specified
+	DefaultPageSize = 10
+	// MaxSearchPages Maximum page number accepted by search
+	MaxSearchPages = 100
+	// MaxSearchResults Maximum number of results reachable by paging single search
+	MaxSearchResults = 10000
+)
+
+// ErrPaginationStalled Deep pagination can't advance because too many documents share a single value of field
+var ErrPaginationStalled = errors.New("pagination stalled: too many documents share a single value")
+
+type sliceIterator struct {
+	ctx        context.Context
+	api        APIClient
+	engineName string
+	field      string
+	query      Query
+	pageSize   int
+
+	filters  filter.Filter
+	prepared bool
+
+	slice     DocumentIterator
+	truncated bool
+	from      interface{}
+
+	// Last value of field and ID's of documents with that value
+	last     interface{}
+	boundary map[string]bool
+
+	doc schema.Map
+	err error
+}
+
+// Iterate over all results of search query past the 10,000 results limit of search.
+// Query is sliced by range filters on field which must be a number or date field of schema.
+// Sorting of query is replaced by ascending field. Documents without value of field are not returned.
+//
+// Slicing by "id" is refused: ID's are always text in App Search and range filters don't apply to text,
+// so there is no way to start a slice after the last ID. Add number or date field (e.g. creation time)
+// to schema to iterate over all documents.
+func IterateAllResults(ctx context.Context, api APIClient, engineName, field string, query Query) DocumentIterator {
+	pageSize := 0
+	if query.Page != nil {
+		pageSize = query.Page.Size
+	}
+
+	return &sliceIterator{
+		ctx:        ctx,
+		api:        api,
+		engineName: engineName,
+		field:      field,
+		query:      query,
+		pageSize:   pageSize,
+	}
+}
+
+func (it *sliceIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.prepared {
+		if it.err = it.prepare(); it.err != nil {
+			return false
+		}
+	}
+
+	for {
+		if it.slice == nil {
+			it.slice = it.nextSlice()
+		}
+
+		if it.slice.Next() {
+			doc := it.slice.Doc()
+			hit := schema.ParseHit(doc)
+			id := hit.ID
+			if id == "" {
+				id = fmt.Sprint(hit.Raw["id"])
+			}
+			value := hit.Raw[it.field]
+			// Documents without value are sorted last and can't bound next slice
+			if value == nil {
+				continue
+			}
+
+			if reflect.DeepEqual(value, it.last) {
+				// Boundary documents were already returned by previous slice
+				if it.boundary[id] {
+					continue
+				}
+			} else {
+				it.last = value
+				it.boundary = make(map[string]bool)
+			}
+			it.boundary[id] = true
+
+			it.doc = doc
+			return true
+		}
+
+		if it.err = it.slice.Err(); it.err != nil {
+			return false
+		}
+		// Only documents without value of field are left past the limit of unbounded first slice
+		if !it.truncated || it.last == nil {
+			return false
+		}
+
+		// Whole slice had a single value, next slice would start at the same value
+		if it.from != nil && reflect.DeepEqual(it.from, it.last) {
+			it.err = fmt.Errorf("%w: %s = %v", ErrPaginationStalled, it.field, it.last)
+			return false
+		}
+
+		it.from = it.last
+		it.slice = nil
+	}
+}
+
+func (it *sliceIterator) Doc() schema.Map {
+	return it.doc
+}
+
+func (it *sliceIterator) Err() error {
+	return it.err
+}
+
+// Validate slicing field against schema and parse query filters
+func (it *sliceIterator) prepare() error {
+	it.prepared = true
+
+	// ID's are not part of schema, but are text all the same
+	if it.field == "id" {
+		return fmt.Errorf("%w: range on text field id", filter.ErrFieldType)
+	}
+
+	def, err := it.api.ListSchema(it.ctx, it.engineName)
+	if err != nil {
+		return err
+	}
+	if err = (filter.Range{Field: it.field}).Validate(def); err != nil {
+		return err
+	}
+
+	if it.query.Filters != nil {
+		it.filters, err = filter.FromMap(it.query.Filters)
+	}
+	return err
+}
+
+// Search slice starting from last value of previous slice, limited by reachable pages
+func (it *sliceIterator) nextSlice() DocumentIterator {
+	query := it.query
+	query.Sort = Sorting{it.field: "asc"}
+	if it.from != nil {
+		var bound filter.Filter = filter.Range{Field: it.field, From: it.from}
+		if it.filters != nil {
+			bound = filter.And{it.filters, bound}
+		}
+		query.Filters = bound.Map()
+	}
+
+	pageSize := it.pageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	maxPages := MaxSearchResults / pageSize
+	if maxPages > MaxSearchPages {
+		maxPages = MaxSearchPages
+	}
+
+	it.truncated = false
+	return &pageIterator{
+		ctx: it.ctx,
+		fetch: func(ctx context.Context, page int) (DocumentResponse, error) {
+			query.Page = &Page{Page: page, Size: pageSize}
+			response, err := it.api.SearchDocuments(ctx, it.engineName, query)
+			if err == nil && response.Meta.Page.TotalPages > maxPages {
+				it.truncated = true
+				response.Meta.Page.TotalPages = maxPages
+			}
+			return response, err
+		},
+	}
+}
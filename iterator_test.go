@@ -0,0 +1,239 @@
+package appsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/filter"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+func TestIterator(t *testing.T) {
+	t.Run("IterateDocuments must walk all pages", func(t *testing.T) {
+		var requested []int
+		api := stubDocumentAPI{list: func(ctx context.Context, engineName string, page Page) (DocumentResponse, error) {
+			requested = append(requested, page.Page)
+			return pageOfDocuments(25, page), nil
+		}}
+
+		it := IterateDocuments(context.TODO(), api, "engine", 10)
+		var count int
+		for it.Next() {
+			require.EqualValues(t, strconv.Itoa(count), it.Doc()["id"])
+			count++
+		}
+		require.NoError(t, it.Err())
+		require.EqualValues(t, 25, count)
+		require.EqualValues(t, []int{1, 2, 3}, requested)
+	})
+
+	t.Run("IterateDocuments must request pages from server", func(t *testing.T) {
+		c, requests := recordingClient(t, func(r recordedRequest) interface{} {
+			var body struct{ Page Page }
+			_ = json.Unmarshal([]byte(r.Body), &body)
+			return pageOfDocuments(25, body.Page)
+		})
+
+		it := IterateDocuments(context.TODO(), c, "engine", 10)
+		var count int
+		for it.Next() {
+			require.EqualValues(t, strconv.Itoa(count), it.Doc()["id"])
+			count++
+		}
+		require.NoError(t, it.Err())
+		require.EqualValues(t, 25, count)
+		require.Len(t, requests(), 3)
+		for i, request := range requests() {
+			require.Equal(t, "engines/engine/documents/list", request.Path)
+			require.JSONEq(t, `{"page": {"current": `+strconv.Itoa(i+1)+`, "size": 10}}`, request.Body)
+		}
+	})
+
+	t.Run("IterateSearch must preserve query and page size", func(t *testing.T) {
+		api := stubDocumentAPI{search: func(ctx context.Context, engineName string, query Query) (DocumentResponse, error) {
+			require.EqualValues(t, "parks", query.Query)
+			return pageOfDocuments(5, *query.Page), nil
+		}}
+
+		it := IterateSearch(context.TODO(), api, "engine", Query{Query: "parks", Page: &Page{Page: 3, Size: 2}})
+		var count int
+		for it.Next() {
+			count++
+		}
+		require.NoError(t, it.Err())
+		require.EqualValues(t, 5, count)
+	})
+
+	t.Run("Must stop on error", func(t *testing.T) {
+		failure := errors.New("failure")
+		api := stubDocumentAPI{list: func(ctx context.Context, engineName string, page Page) (DocumentResponse, error) {
+			if page.Page == 2 {
+				return DocumentResponse{}, failure
+			}
+			return pageOfDocuments(25, page), nil
+		}}
+
+		it := IterateDocuments(context.TODO(), api, "engine", 10)
+		var count int
+		for it.Next() {
+			count++
+		}
+		require.ErrorIs(t, it.Err(), failure)
+		require.EqualValues(t, 10, count)
+	})
+
+	t.Run("Must respect context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		api := stubDocumentAPI{list: func(ctx context.Context, engineName string, page Page) (DocumentResponse, error) {
+			return pageOfDocuments(25, page), nil
+		}}
+
+		it := IterateDocuments(ctx, api, "engine", 10)
+		require.True(t, it.Next())
+		cancel()
+
+		var count int
+		for it.Next() {
+			count++
+		}
+		require.ErrorIs(t, it.Err(), context.Canceled)
+		require.EqualValues(t, 9, count)
+	})
+}
+
+// Search over sorted documents (without "n" last) supporting only range filter on field "n"
+type stubSliceAPI struct {
+	APIClient
+	documents []schema.Map
+	filters   []SearchFilters
+}
+
+func (s *stubSliceAPI) ListSchema(ctx context.Context, engineName string) (schema.Definition, error) {
+	return schema.Definition{"n": schema.TypeNumber, "title": schema.TypeText}, nil
+}
+
+func (s *stubSliceAPI) SearchDocuments(ctx context.Context, engineName string, query Query) (DocumentResponse, error) {
+	s.filters = append(s.filters, query.Filters)
+	if query.Page.Page > MaxSearchPages {
+		return DocumentResponse{}, errors.New("page out of range")
+	}
+
+	documents := s.documents
+	var bound filter.Filter
+	if query.Filters != nil {
+		var err error
+		if bound, err = filter.FromMap(query.Filters); err != nil {
+			return DocumentResponse{}, err
+		}
+	}
+	if and, ok := bound.(filter.And); ok {
+		bound = and[len(and)-1]
+	}
+	if bound, ok := bound.(filter.Range); ok {
+		var bounded []schema.Map
+		for _, document := range documents {
+			if n, ok := schema.ParseHit(document).Raw["n"].(float64); ok && n >= bound.From.(float64) {
+				bounded = append(bounded, document)
+			}
+		}
+		documents = bounded
+	}
+
+	total := len(documents)
+	page := *query.Page
+	response := DocumentResponse{Meta: ResponseMeta{Page: PaginationMeta{
+		TotalPages:   (total + page.Size - 1) / page.Size,
+		TotalResults: total,
+	}}}
+	for i := (page.Page - 1) * page.Size; i < page.Page*page.Size && i < total; i++ {
+		response.Results = append(response.Results, documents[i])
+	}
+	return response, nil
+}
+
+func sliceDocuments(total int, value func(i int) float64) []schema.Map {
+	documents := make([]schema.Map, total)
+	for i := range documents {
+		documents[i] = schema.Map{
+			"_meta": schema.Map{"id": strconv.Itoa(i)},
+			"n":     schema.Map{"raw": value(i)},
+		}
+	}
+	return documents
+}
+
+func TestIterateAllResults(t *testing.T) {
+	t.Run("Must skip documents without value", func(t *testing.T) {
+		for _, valued := range []int{500, 0} {
+			documents := sliceDocuments(valued, func(i int) float64 { return float64(i) })
+			for i := 0; i < 600; i++ {
+				documents = append(documents, schema.Map{"_meta": schema.Map{"id": "none-" + strconv.Itoa(i)}})
+			}
+			api := &stubSliceAPI{documents: documents}
+
+			it := IterateAllResults(context.TODO(), api, "engine", "n", Query{})
+			var count int
+			for it.Next() && count <= len(documents) {
+				require.NotNil(t, schema.ParseHit(it.Doc()).Raw["n"])
+				count++
+			}
+			require.NoError(t, it.Err())
+			require.EqualValues(t, valued, count)
+		}
+	})
+
+	t.Run("Must return every document past search limit", func(t *testing.T) {
+		api := &stubSliceAPI{documents: sliceDocuments(25000, func(i int) float64 { return float64(i / 7) })}
+
+		it := IterateAllResults(context.TODO(), api, "engine", "n", Query{Page: &Page{Size: 100}})
+		seen := make(map[string]bool)
+		for it.Next() {
+			id := schema.ParseHit(it.Doc()).ID
+			require.False(t, seen[id], "duplicate %s", id)
+			seen[id] = true
+		}
+		require.NoError(t, it.Err())
+		require.Len(t, seen, 25000)
+	})
+
+	t.Run("Must keep original filters", func(t *testing.T) {
+		api := &stubSliceAPI{documents: sliceDocuments(20, func(i int) float64 { return float64(i) })}
+
+		original := filter.Value{Field: "title", Values: []interface{}{"park"}}
+		it := IterateAllResults(context.TODO(), api, "engine", "n", Query{Filters: original.Map()})
+		var count int
+		for it.Next() {
+			count++
+		}
+		require.NoError(t, it.Err())
+		require.EqualValues(t, 20, count)
+		require.Equal(t, []SearchFilters{original.Map(), original.Map()}, api.filters)
+	})
+
+	t.Run("Must refuse text field", func(t *testing.T) {
+		it := IterateAllResults(context.TODO(), &stubSliceAPI{}, "engine", "title", Query{})
+		require.False(t, it.Next())
+		require.ErrorIs(t, it.Err(), filter.ErrFieldType)
+
+		it = IterateAllResults(context.TODO(), &stubSliceAPI{}, "engine", "id", Query{})
+		require.False(t, it.Next())
+		require.ErrorIs(t, it.Err(), filter.ErrFieldType)
+	})
+
+	t.Run("Must fail when single value exceeds search limit", func(t *testing.T) {
+		api := &stubSliceAPI{documents: sliceDocuments(MaxSearchResults+1, func(i int) float64 { return 0 })}
+
+		it := IterateAllResults(context.TODO(), api, "engine", "n", Query{Page: &Page{Size: 100}})
+		var count int
+		for it.Next() {
+			count++
+		}
+		require.ErrorIs(t, it.Err(), ErrPaginationStalled)
+		require.EqualValues(t, MaxSearchResults, count)
+	})
+}
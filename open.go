@@ -3,41 +3,122 @@ package appsearch
 import (
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/go-resty/resty/v2"
+
+	"github.com/lithiumlabcompany/appsearch/internal/pkg/ratelimit"
 )
 
+// Base path of App Search API
+const defaultBasePath = "/api/as/v1/"
+
 // Open APIClient with endpoint and key
 // First parameter may be specified as URL with API key in authentication like:
 // https://private-...@abcd.ent-search.eu-central-1.aws.cloud.es.io
 // Second parameter is always interpreted as API key if specified
+// Transient failures are retried with DefaultRetryPolicy
 func Open(endpointAndKey ...string) (APIClient, error) {
-	hostURL, token, authType, err := getHostURL(endpointAndKey)
+	switch len(endpointAndKey) {
+	case 2:
+		return OpenWithOptions(endpointAndKey[0], WithKey(endpointAndKey[1]))
+	case 1:
+		return OpenWithOptions(endpointAndKey[0])
+	default:
+		return nil, ErrInvalidParams
+	}
+}
+
+// Open APIClient with endpoint (see Open) and options
+func OpenWithOptions(endpoint string, opts ...Option) (APIClient, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	hostURL, token, authType, err := resolveWithBasePath(endpoint, o.basePath)
+	if err != nil {
+		return nil, err
+	}
+	if o.key != "" {
+		authType = "Bearer"
+		token = o.key
+	}
+
+	httpClient, err := newHTTPClient(o)
+	if err != nil {
+		return nil, err
+	}
+
+	// App Search takes parameters of GET requests (like page) in JSON body,
+	// which resty drops unless allowed
+	c := resty.NewWithClient(httpClient).SetAllowGetMethodPayload(true)
+	if o.logger != nil {
+		c.SetLogger(o.logger)
+	}
+	if o.userAgent != "" {
+		c.SetHeader("User-Agent", o.userAgent)
+	}
 
 	return &client{
-		resty.New().
+		Client: c.
 			SetHostURL(hostURL).
 			SetAuthToken(token).
 			SetAuthScheme(authType),
-	}, err
+		retry:        o.retry,
+		readLimiter:  ratelimit.New(o.rateLimit.Read, o.rateLimit.ReadBurst),
+		writeLimiter: ratelimit.New(o.rateLimit.Write, o.rateLimit.WriteBurst),
+	}, nil
 }
 
-func getHostURL(params []string) (hostURL string, token string, authType string, err error) {
-	switch len(params) {
-	case 2:
-		hostURL, _, _, err = resolve(params[0])
-		authType = "Bearer"
-		token = params[1]
-		return
-	case 1:
-		return resolve(params[0])
-	default:
-		return "", "", "", ErrInvalidParams
+// Copy of HTTP client (and its transport) with options applied,
+// so client passed with WithHTTPClient and http.DefaultTransport are never changed.
+func newHTTPClient(o options) (*http.Client, error) {
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		clone := *o.httpClient
+		httpClient = &clone
+	}
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+
+	if o.tlsConfig == nil && o.proxy == "" {
+		return httpClient, nil
+	}
+
+	roundTripper := httpClient.Transport
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
 	}
+	transport, ok := roundTripper.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("%w: TLS config and proxy require *http.Transport, not %T", ErrInvalidOption, roundTripper)
+	}
+	transport = transport.Clone()
+
+	if o.tlsConfig != nil {
+		transport.TLSClientConfig = o.tlsConfig.Clone()
+	}
+	if o.proxy != "" {
+		proxyURL, err := url.Parse(o.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("%w: proxy: %v", ErrInvalidOption, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	httpClient.Transport = transport
+	return httpClient, nil
 }
 
 func resolve(rawURL string) (hostURL string, token string, authType string, err error) {
+	return resolveWithBasePath(rawURL, defaultBasePath)
+}
+
+func resolveWithBasePath(rawURL, basePath string) (hostURL string, token string, authType string, err error) {
 	u, err := url.Parse(rawURL)
 	if err != nil {
 		return
@@ -56,6 +137,10 @@ func resolve(rawURL string) (hostURL string, token string, authType string, err
 		}
 		u.User = nil
 	}
-	hostURL = u.ResolveReference(&url.URL{Path: "/api/as/v1/"}).String()
+	if !strings.HasSuffix(basePath, "/") {
+		// Keep last path segment when resolving API paths
+		basePath += "/"
+	}
+	hostURL = u.ResolveReference(&url.URL{Path: basePath}).String()
 	return
 }
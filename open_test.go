@@ -1,7 +1,16 @@
 package appsearch
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -52,4 +61,161 @@ func TestOpen(t *testing.T) {
 			require.NoError(t, err)
 		})
 	})
+	t.Run("OpenWithOptions", func(t *testing.T) {
+		t.Run("Must apply options", func(t *testing.T) {
+			var request *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"name":"engine"}`))
+			}))
+			defer server.Close()
+
+			logger := &recordingLogger{}
+			policy := RetryPolicy{MaxAttempts: 5}
+			c, err := OpenWithOptions("http://token@"+strings.TrimPrefix(server.URL, "http://"),
+				WithKey("key"),
+				WithUserAgent("agent"),
+				WithBasePath("/search/api/"),
+				WithRetry(policy),
+				WithRateLimit(RateLimit{Read: 100, Write: 10}),
+				WithLogger(logger),
+			)
+			require.NoError(t, err)
+			c.(*client).SetDebug(true)
+
+			engine, err := c.ListEngine(context.TODO(), "engine")
+			require.NoError(t, err)
+			require.Equal(t, "engine", engine.Name)
+			require.Equal(t, "/search/api/engines/engine", request.URL.Path)
+			require.Equal(t, "Bearer key", request.Header.Get("Authorization"))
+			require.Equal(t, "agent", request.Header.Get("User-Agent"))
+			require.NotEmpty(t, logger.messages)
+			require.Equal(t, policy, c.(*client).retry)
+			require.NotNil(t, c.(*client).readLimiter)
+			require.NotNil(t, c.(*client).writeLimiter)
+		})
+
+		t.Run("Must send body of GET request", func(t *testing.T) {
+			c, requests := recordingClient(t, func(r recordedRequest) interface{} {
+				return EngineResponse{}
+			})
+
+			_, err := c.ListEngines(context.TODO(), Page{Page: 2, Size: 5})
+			require.NoError(t, err)
+			require.Len(t, requests(), 1)
+			require.Equal(t, http.MethodGet, requests()[0].Method)
+			require.Equal(t, "engines", requests()[0].Path)
+			require.JSONEq(t, `{"page": {"current": 2, "size": 5}}`, requests()[0].Body)
+		})
+
+		t.Run("Must apply timeout", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+			}))
+			defer server.Close()
+
+			c, err := OpenWithOptions(server.URL, WithTimeout(20*time.Millisecond), WithRetry(RetryPolicy{}))
+			require.NoError(t, err)
+
+			_, err = c.ListEngine(context.TODO(), "engine")
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "Client.Timeout")
+		})
+
+		t.Run("Must apply TLS config", func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"name":"engine"}`))
+			}))
+			defer server.Close()
+
+			c, err := OpenWithOptions(server.URL, WithRetry(RetryPolicy{}))
+			require.NoError(t, err)
+			_, err = c.ListEngine(context.TODO(), "engine")
+			require.Error(t, err)
+
+			roots := x509.NewCertPool()
+			roots.AddCert(server.Certificate())
+			c, err = OpenWithOptions(server.URL, WithTLSConfig(&tls.Config{RootCAs: roots}))
+			require.NoError(t, err)
+			_, err = c.ListEngine(context.TODO(), "engine")
+			require.NoError(t, err)
+		})
+
+		t.Run("Must apply proxy", func(t *testing.T) {
+			var proxied string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxied = r.URL.Host
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"name":"engine"}`))
+			}))
+			defer proxy.Close()
+
+			c, err := OpenWithOptions("http://appsearch.invalid", WithProxy(proxy.URL))
+			require.NoError(t, err)
+			_, err = c.ListEngine(context.TODO(), "engine")
+			require.NoError(t, err)
+			require.Equal(t, "appsearch.invalid", proxied)
+		})
+
+		t.Run("Must not change HTTP client and default transport", func(t *testing.T) {
+			transport := &http.Transport{TLSClientConfig: &tls.Config{ServerName: "original"}}
+			httpClient := &http.Client{Transport: transport}
+			tlsConfig := &tls.Config{ServerName: "appsearch"}
+
+			c, err := OpenWithOptions("https://host",
+				WithHTTPClient(httpClient),
+				WithTimeout(time.Second),
+				WithTLSConfig(tlsConfig),
+				WithProxy("http://proxy:8080"),
+			)
+			require.NoError(t, err)
+			require.Zero(t, httpClient.Timeout)
+			require.Equal(t, "original", transport.TLSClientConfig.ServerName)
+			require.Nil(t, transport.Proxy)
+
+			applied := c.(*client).GetClient()
+			require.Equal(t, time.Second, applied.Timeout)
+			require.Equal(t, "appsearch", applied.Transport.(*http.Transport).TLSClientConfig.ServerName)
+
+			defaultTLSConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig
+			_, err = OpenWithOptions("https://host", WithHTTPClient(http.DefaultClient), WithTLSConfig(tlsConfig))
+			require.NoError(t, err)
+			require.Same(t, defaultTLSConfig, http.DefaultTransport.(*http.Transport).TLSClientConfig)
+		})
+
+		t.Run("Must return error for options which can't be applied", func(t *testing.T) {
+			_, err := OpenWithOptions("%")
+			require.Error(t, err)
+
+			_, err = OpenWithOptions("https://host", WithProxy("%"))
+			require.ErrorIs(t, err, ErrInvalidOption)
+
+			custom := &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+			_, err = OpenWithOptions("https://host", WithHTTPClient(custom), WithTLSConfig(&tls.Config{}))
+			require.ErrorIs(t, err, ErrInvalidOption)
+		})
+	})
+}
+
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+func (l *recordingLogger) Errorf(format string, v ...interface{}) { l.record(format, v...) }
+func (l *recordingLogger) Warnf(format string, v ...interface{})  { l.record(format, v...) }
+func (l *recordingLogger) Debugf(format string, v ...interface{}) { l.record(format, v...) }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
 }
@@ -0,0 +1,121 @@
+package appsearch
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// Logger Logger of client (compatible with resty.Logger)
+type Logger interface {
+	Errorf(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Debugf(format string, v ...interface{})
+}
+
+// RateLimit Limit of requests per second (unlimited if not positive).
+// Read requests are GET requests and searches, everything else is a write request.
+type RateLimit struct {
+	// Read requests per second
+	Read float64
+	// Read requests allowed at once (Read rounded up if not specified)
+	ReadBurst int
+	// Write requests per second
+	Write float64
+	// Write requests allowed at once (Write rounded up if not specified)
+	WriteBurst int
+}
+
+// Option Option of OpenWithOptions
+type Option func(o *options)
+
+type options struct {
+	key        string
+	httpClient *http.Client
+	timeout    time.Duration
+	tlsConfig  *tls.Config
+	userAgent  string
+	retry      RetryPolicy
+	logger     Logger
+	proxy      string
+	basePath   string
+	rateLimit  RateLimit
+}
+
+func defaultOptions() options {
+	return options{
+		retry:    DefaultRetryPolicy(),
+		basePath: defaultBasePath,
+	}
+}
+
+// WithKey Use API key (overrides key specified in endpoint URL)
+func WithKey(key string) Option {
+	return func(o *options) {
+		o.key = key
+	}
+}
+
+// WithHTTPClient Use copy of HTTP client (other options are applied to the copy, client is not changed).
+// WithTLSConfig and WithProxy require its transport to be *http.Transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTimeout Use timeout of a single request attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithTLSConfig Use TLS config of transport
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithUserAgent Use User-Agent header
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithRetry Use retry policy instead of DefaultRetryPolicy
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithLogger Use logger of client
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithProxy Use proxy URL
+func WithProxy(proxyURL string) Option {
+	return func(o *options) {
+		o.proxy = proxyURL
+	}
+}
+
+// WithRateLimit Limit rate of requests sent by client (shared by all goroutines using it)
+func WithRateLimit(limit RateLimit) Option {
+	return func(o *options) {
+		o.rateLimit = limit
+	}
+}
+
+// WithBasePath Use base path of API instead of /api/as/v1/ (e.g. behind reverse proxy)
+func WithBasePath(basePath string) Option {
+	return func(o *options) {
+		o.basePath = basePath
+	}
+}
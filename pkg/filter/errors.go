@@ -0,0 +1,16 @@
+package filter
+
+import (
+	"errors"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+var (
+	// Field is not defined in schema
+	ErrUnknownField = schema.ErrUnknownField
+	// Field type doesn't support the operation
+	ErrFieldType = schema.ErrFieldType
+	// Filter JSON can't be parsed
+	ErrInvalidFilter = errors.New("invalid filter")
+)
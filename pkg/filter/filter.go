@@ -0,0 +1,203 @@
+// Package filter represents App Search filters as a tree of typed nodes.
+// Filters convert to and parse from App Search filter maps (appsearch.SearchFilters) and JSON,
+// so they can be inspected and transformed before search.
+package filter
+
+import (
+	"encoding/json"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+// Filter node
+type Filter interface {
+	json.Marshaler
+	// Filter as App Search filter map (assignable to appsearch.SearchFilters)
+	Map() schema.Map
+	// Validate filter against schema definition
+	Validate(def schema.Definition) error
+}
+
+// And All filters must match ("all")
+type And []Filter
+
+// Or Any of filters must match ("any")
+type Or []Filter
+
+// Not None of filters must match ("none")
+type Not []Filter
+
+// Value Field must be equal to any of values
+type Value struct {
+	Field  string
+	Values []interface{}
+}
+
+// Range Field must be in range [From, To). Nil From or To leaves range open.
+type Range struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+// DistanceUnit Unit of GeoDistance
+type DistanceUnit = string
+
+const (
+	// Millimeters Distance unit
+	Millimeters DistanceUnit = "mm"
+	// Centimeters Distance unit
+	Centimeters DistanceUnit = "cm"
+	// Meters Distance unit
+	Meters DistanceUnit = "m"
+	// Kilometers Distance unit
+	Kilometers DistanceUnit = "km"
+	// Inches Distance unit
+	Inches DistanceUnit = "in"
+	// Feet Distance unit
+	Feet DistanceUnit = "ft"
+	// Yards Distance unit
+	Yards DistanceUnit = "yd"
+	// Miles Distance unit
+	Miles DistanceUnit = "mi"
+)
+
+// GeoDistance Field must be within Distance from Center ("latitude, longitude")
+// or within From and To distances if Distance is zero.
+type GeoDistance struct {
+	Field    string
+	Center   string
+	Distance float64
+	From     interface{}
+	To       interface{}
+	Unit     DistanceUnit
+}
+
+// Map as {"all": [...]}
+func (f And) Map() schema.Map {
+	return schema.Map{"all": maps(f)}
+}
+
+// Map as {"any": [...]}
+func (f Or) Map() schema.Map {
+	return schema.Map{"any": maps(f)}
+}
+
+// Map as {"none": [...]}
+func (f Not) Map() schema.Map {
+	return schema.Map{"none": maps(f)}
+}
+
+// Map as {"field": value} or {"field": [values]}
+func (f Value) Map() schema.Map {
+	if len(f.Values) == 1 {
+		return schema.Map{f.Field: f.Values[0]}
+	}
+	return schema.Map{f.Field: f.Values}
+}
+
+// Map as {"field": {"from": from, "to": to}}
+func (f Range) Map() schema.Map {
+	return schema.Map{f.Field: bounds(schema.Map{}, f.From, f.To)}
+}
+
+// Map as {"field": {"center": center, "distance": distance, "unit": unit}}
+func (f GeoDistance) Map() schema.Map {
+	geo := schema.Map{
+		"center": f.Center,
+		"unit":   f.Unit,
+	}
+	if f.Distance != 0 {
+		geo["distance"] = f.Distance
+	} else {
+		bounds(geo, f.From, f.To)
+	}
+	return schema.Map{f.Field: geo}
+}
+
+// MarshalJSON as Map
+func (f And) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Map())
+}
+
+// MarshalJSON as Map
+func (f Or) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Map())
+}
+
+// MarshalJSON as Map
+func (f Not) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Map())
+}
+
+// MarshalJSON as Map
+func (f Value) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Map())
+}
+
+// MarshalJSON as Map
+func (f Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Map())
+}
+
+// MarshalJSON as Map
+func (f GeoDistance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Map())
+}
+
+func maps(filters []Filter) []interface{} {
+	list := make([]interface{}, len(filters))
+	for i, filter := range filters {
+		list[i] = filter.Map()
+	}
+	return list
+}
+
+func bounds(m schema.Map, from, to interface{}) schema.Map {
+	if from != nil {
+		m["from"] = from
+	}
+	if to != nil {
+		m["to"] = to
+	}
+	return m
+}
+
+// Validate every filter
+func (f And) Validate(def schema.Definition) error {
+	return validateAll(def, f)
+}
+
+// Validate every filter
+func (f Or) Validate(def schema.Definition) error {
+	return validateAll(def, f)
+}
+
+// Validate every filter
+func (f Not) Validate(def schema.Definition) error {
+	return validateAll(def, f)
+}
+
+// Validate field is text, number or date
+func (f Value) Validate(def schema.Definition) error {
+	return def.ValidateField(f.Field, "value filter", schema.TypeText, schema.TypeNumber, schema.TypeDate)
+}
+
+// Validate field is number or date
+func (f Range) Validate(def schema.Definition) error {
+	return def.ValidateField(f.Field, "range filter", schema.TypeNumber, schema.TypeDate)
+}
+
+// Validate field is geolocation
+func (f GeoDistance) Validate(def schema.Definition) error {
+	return def.ValidateField(f.Field, "geo distance filter", schema.TypeGeolocation)
+}
+
+func validateAll(def schema.Definition, filters []Filter) error {
+	for _, filter := range filters {
+		if err := filter.Validate(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+func TestFilter(t *testing.T) {
+	tree := And{
+		Or{
+			Value{Field: "states", Values: []interface{}{"Utah", "Nevada"}},
+			Not{Value{Field: "title", Values: []interface{}{"Closed"}}},
+		},
+		Range{Field: "rating", From: 4.0},
+		GeoDistance{Field: "location", Center: "37.38, -122.08", Distance: 300, Unit: Kilometers},
+	}
+	data := `{"all": [
+		{"any": [{"states": ["Utah", "Nevada"]}, {"none": [{"title": "Closed"}]}]},
+		{"rating": {"from": 4}},
+		{"location": {"center": "37.38, -122.08", "distance": 300, "unit": "km"}}
+	]}`
+
+	t.Run("Must marshal filter tree", func(t *testing.T) {
+		b, err := json.Marshal(tree)
+		require.NoError(t, err)
+		require.JSONEq(t, data, string(b))
+	})
+
+	t.Run("Must parse filter JSON", func(t *testing.T) {
+		filter, err := Parse([]byte(data))
+		require.NoError(t, err)
+		require.EqualValues(t, tree, filter)
+	})
+
+	t.Run("Must convert filter tree to map and back", func(t *testing.T) {
+		filter, err := FromMap(tree.Map())
+		require.NoError(t, err)
+		require.EqualValues(t, tree, filter)
+	})
+
+	t.Run("Must combine keys of filter map with and", func(t *testing.T) {
+		filter, err := FromMap(schema.Map{
+			"states": []string{"Utah"},
+			"rating": schema.Map{"from": 1, "to": 5},
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, And{
+			Range{Field: "rating", From: 1, To: 5},
+			Value{Field: "states", Values: []interface{}{"Utah"}},
+		}, filter)
+	})
+
+	t.Run("Must reject invalid filter", func(t *testing.T) {
+		_, err := Parse([]byte(`{"all": "states"}`))
+		require.ErrorIs(t, err, ErrInvalidFilter)
+	})
+
+	t.Run("Must validate filter against schema", func(t *testing.T) {
+		def := schema.Definition{
+			"states":   "text",
+			"title":    "text",
+			"rating":   "number",
+			"location": "geolocation",
+		}
+		require.NoError(t, tree.Validate(def))
+
+		err := And{Range{Field: "title", From: 1}}.Validate(def)
+		require.ErrorIs(t, err, ErrFieldType)
+
+		err = Not{Value{Field: "missing"}}.Validate(def)
+		require.ErrorIs(t, err, ErrUnknownField)
+	})
+}
@@ -0,0 +1,130 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+// Parse App Search filter JSON
+func Parse(data []byte) (Filter, error) {
+	var m schema.Map
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return FromMap(m)
+}
+
+// Convert filter map (e.g. appsearch.SearchFilters) into filter tree.
+// Multiple keys of a map are combined with And.
+func FromMap(m schema.Map) (Filter, error) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	filters := make(And, len(keys))
+	for i, key := range keys {
+		filter, err := parseKey(key, m[key])
+		if err != nil {
+			return nil, err
+		}
+		filters[i] = filter
+	}
+
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return filters, nil
+}
+
+func parseKey(key string, value interface{}) (Filter, error) {
+	switch key {
+	case "all":
+		filters, err := parseList(value)
+		return And(filters), err
+	case "any":
+		filters, err := parseList(value)
+		return Or(filters), err
+	case "none":
+		filters, err := parseList(value)
+		return Not(filters), err
+	}
+
+	if m, isMap := value.(schema.Map); isMap {
+		if _, isGeo := m["center"]; isGeo {
+			return parseGeoDistance(key, m)
+		}
+		return Range{Field: key, From: m["from"], To: m["to"]}, nil
+	}
+
+	if values := reflect.ValueOf(value); values.Kind() == reflect.Slice {
+		filter := Value{Field: key, Values: make([]interface{}, values.Len())}
+		for i := range filter.Values {
+			filter.Values[i] = values.Index(i).Interface()
+		}
+		return filter, nil
+	}
+
+	return Value{Field: key, Values: []interface{}{value}}, nil
+}
+
+// Combined filters are specified as a list of filters or a single filter
+func parseList(value interface{}) ([]Filter, error) {
+	switch value := value.(type) {
+	case schema.Map:
+		filter, err := FromMap(value)
+		return []Filter{filter}, err
+	case []interface{}:
+		filters := make([]Filter, len(value))
+		for i, item := range value {
+			m, ok := item.(schema.Map)
+			if !ok {
+				return nil, fmt.Errorf("%w: %v is not an object", ErrInvalidFilter, item)
+			}
+			filter, err := FromMap(m)
+			if err != nil {
+				return nil, err
+			}
+			filters[i] = filter
+		}
+		return filters, nil
+	default:
+		return nil, fmt.Errorf("%w: %v is not a list of filters", ErrInvalidFilter, value)
+	}
+}
+
+func parseGeoDistance(field string, value schema.Map) (Filter, error) {
+	geo := GeoDistance{
+		Field: field,
+		From:  value["from"],
+		To:    value["to"],
+	}
+
+	var ok bool
+	if geo.Center, ok = value["center"].(string); !ok {
+		return nil, fmt.Errorf("%w: center of %s is not a string", ErrInvalidFilter, field)
+	}
+	if unit, present := value["unit"]; present {
+		if geo.Unit, ok = unit.(string); !ok {
+			return nil, fmt.Errorf("%w: unit of %s is not a string", ErrInvalidFilter, field)
+		}
+	}
+	if distance, present := value["distance"]; present {
+		number := reflect.ValueOf(distance)
+		switch number.Kind() {
+		case reflect.Float32, reflect.Float64:
+			geo.Distance = number.Float()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			geo.Distance = float64(number.Int())
+		default:
+			return nil, fmt.Errorf("%w: distance of %s is not a number", ErrInvalidFilter, field)
+		}
+	}
+
+	return geo, nil
+}
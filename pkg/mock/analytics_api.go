@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/lithiumlabcompany/appsearch"
+)
+
+func (m *mock) LogClickthrough(ctx context.Context, engineName string, request appsearch.ClickRequest) (err error) {
+	m.Clicks[engineName] = append(m.Clicks[engineName], request)
+	return nil
+}
+
+func (m *mock) AnalyticsCounts(ctx context.Context, engineName string, request appsearch.AnalyticsCountsRequest) (data appsearch.AnalyticsCountsResponse, err error) {
+	m.impl(interfacesOf(ctx, engineName, request), interfacesOf(&data, &err))
+	return
+}
+
+func (m *mock) TopQueries(ctx context.Context, engineName string, request appsearch.TopQueriesRequest) (data appsearch.TopQueriesResponse, err error) {
+	m.impl(interfacesOf(ctx, engineName, request), interfacesOf(&data, &err))
+	return
+}
+
+func (m *mock) TopClicks(ctx context.Context, engineName string, request appsearch.TopClicksRequest) (data appsearch.TopClicksResponse, err error) {
+	m.impl(interfacesOf(ctx, engineName, request), interfacesOf(&data, &err))
+	return
+}
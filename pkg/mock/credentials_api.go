@@ -0,0 +1,105 @@
+package mock
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/lithiumlabcompany/appsearch"
+)
+
+func (m *mock) ListCredentials(ctx context.Context, page appsearch.Page) (data appsearch.CredentialResponse, err error) {
+	keys := credentialValues(m.Credentials)
+	from, to, meta := paginate(len(keys), page)
+
+	return appsearch.CredentialResponse{
+		Meta:    appsearch.ResponseMeta{Page: meta},
+		Results: keys[from:to],
+	}, nil
+}
+
+func (m *mock) ListCredential(ctx context.Context, keyName string) (data appsearch.CredentialDescription, err error) {
+	data, ok := m.Credentials[keyName]
+	if !ok {
+		err = appsearch.ErrCredentialDoesntExist
+	}
+	return
+}
+
+func (m *mock) CreateCredential(ctx context.Context, request appsearch.CredentialRequest) (data appsearch.CredentialDescription, err error) {
+	if _, ok := m.Credentials[request.Name]; ok {
+		return data, appsearch.ErrCredentialAlreadyExists
+	}
+
+	data = credentialFromRequest(request)
+	data.ID = "cred-" + uuid.New().String()
+	data.Key = randomKey(request.Type)
+	m.Credentials[data.Name] = data
+	return data, nil
+}
+
+func (m *mock) UpdateCredential(ctx context.Context, keyName string, request appsearch.CredentialRequest) (data appsearch.CredentialDescription, err error) {
+	current, err := m.ListCredential(ctx, keyName)
+	if err != nil {
+		return
+	}
+
+	data = credentialFromRequest(request)
+	data.ID = current.ID
+	data.Key = current.Key
+	delete(m.Credentials, keyName)
+	m.Credentials[data.Name] = data
+	return data, nil
+}
+
+func (m *mock) RotateCredential(ctx context.Context, keyName string) (data appsearch.CredentialDescription, err error) {
+	current, err := m.ListCredential(ctx, keyName)
+	if err != nil {
+		return
+	}
+
+	err = m.DeleteCredential(ctx, keyName)
+	if err != nil {
+		return
+	}
+
+	return m.CreateCredential(ctx, current.Request())
+}
+
+func (m *mock) DeleteCredential(ctx context.Context, keyName string) (err error) {
+	_, err = m.ListCredential(ctx, keyName)
+	if err != nil {
+		return
+	}
+
+	delete(m.Credentials, keyName)
+	return nil
+}
+
+func credentialFromRequest(request appsearch.CredentialRequest) appsearch.CredentialDescription {
+	return appsearch.CredentialDescription{
+		Name:             request.Name,
+		Type:             request.Type,
+		Read:             request.Read,
+		Write:            request.Write,
+		AccessAllEngines: request.AccessAllEngines,
+		Engines:          request.Engines,
+	}
+}
+
+func randomKey(keyType appsearch.KeyType) string {
+	return keyType + "-" + strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+func credentialValues(keys map[string]appsearch.CredentialDescription) []appsearch.CredentialDescription {
+	values := make([]appsearch.CredentialDescription, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, key)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
+	return values
+}
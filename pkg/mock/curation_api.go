@@ -0,0 +1,72 @@
+package mock
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/lithiumlabcompany/appsearch"
+)
+
+func (m *mock) ListCurations(ctx context.Context, engineName string, page appsearch.Page) (data appsearch.CurationResponse, err error) {
+	curations := curationValues(m.Curations[engineName])
+	from, to, meta := paginate(len(curations), page)
+
+	return appsearch.CurationResponse{
+		Meta:    appsearch.ResponseMeta{Page: meta},
+		Results: curations[from:to],
+	}, nil
+}
+
+func (m *mock) ListCuration(ctx context.Context, engineName string, curationID string) (data appsearch.Curation, err error) {
+	data, ok := m.Curations[engineName][curationID]
+	if !ok {
+		err = appsearch.ErrCurationDoesntExist
+	}
+	return
+}
+
+func (m *mock) CreateCuration(ctx context.Context, engineName string, request appsearch.CurationRequest) (data appsearch.Curation, err error) {
+	curations, ok := m.Curations[engineName]
+	if !ok {
+		curations = make(map[string]appsearch.Curation)
+		m.Curations[engineName] = curations
+	}
+
+	data = request.Curation("cur-" + uuid.New().String())
+	curations[data.ID] = data
+	return data, nil
+}
+
+func (m *mock) UpdateCuration(ctx context.Context, engineName string, curationID string, request appsearch.CurationRequest) (data appsearch.Curation, err error) {
+	_, err = m.ListCuration(ctx, engineName, curationID)
+	if err != nil {
+		return
+	}
+
+	data = request.Curation(curationID)
+	m.Curations[engineName][curationID] = data
+	return data, nil
+}
+
+func (m *mock) DeleteCuration(ctx context.Context, engineName string, curationID string) (err error) {
+	_, err = m.ListCuration(ctx, engineName, curationID)
+	if err != nil {
+		return
+	}
+
+	delete(m.Curations[engineName], curationID)
+	return nil
+}
+
+func curationValues(curations map[string]appsearch.Curation) []appsearch.Curation {
+	values := make([]appsearch.Curation, 0, len(curations))
+	for _, curation := range curations {
+		values = append(values, curation)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].ID < values[j].ID
+	})
+	return values
+}
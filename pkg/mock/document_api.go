@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/lithiumlabcompany/appsearch"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
 )
 
 func (m *mock) PatchDocuments(ctx context.Context, engineName string, documents interface{}) (res []appsearch.UpdateResponse, err error) {
@@ -21,6 +22,11 @@ func (m *mock) RemoveDocuments(ctx context.Context, engineName string, documents
 	return
 }
 
+func (m *mock) GetDocuments(ctx context.Context, engineName string, ids []string) (documents []schema.Map, err error) {
+	m.impl(interfacesOf(ctx, engineName, ids), interfacesOf(&documents, &err))
+	return
+}
+
 func (m *mock) ListDocuments(ctx context.Context, engineName string, page appsearch.Page) (response appsearch.DocumentResponse, err error) {
 	m.impl(interfacesOf(ctx, engineName, page), interfacesOf(&response, &err))
 	return
@@ -30,3 +36,25 @@ func (m *mock) SearchDocuments(ctx context.Context, engineName string, query app
 	m.impl(interfacesOf(ctx, engineName, query), interfacesOf(&response, &err))
 	return
 }
+
+func (m *mock) QuerySuggestion(ctx context.Context, engineName string, request appsearch.SuggestionRequest) (response appsearch.SuggestionResponse, err error) {
+	m.impl(interfacesOf(ctx, engineName, request), interfacesOf(&response, &err))
+	return
+}
+
+func (m *mock) MultiSearchDocuments(ctx context.Context, engineName string, queries []appsearch.Query) (responses []appsearch.DocumentResponse, err error) {
+	responses = make([]appsearch.DocumentResponse, len(queries))
+	failed := make(map[int]error)
+	for i, query := range queries {
+		responses[i], err = m.SearchDocuments(ctx, engineName, query)
+		if err != nil {
+			failed[i] = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return responses, &appsearch.MultiSearchError{Errors: failed}
+	}
+
+	return responses, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/lithiumlabcompany/appsearch"
+	"github.com/lithiumlabcompany/appsearch/internal/pkg/converge"
 	"github.com/lithiumlabcompany/appsearch/pkg/schema"
 )
 
@@ -32,15 +33,51 @@ func (m *mock) CreateEngine(ctx context.Context, request appsearch.CreateEngineR
 		return desc, appsearch.ErrEngineAlreadyExists
 	}
 
+	engineType := request.Type
+	if engineType == "" {
+		engineType = appsearch.DefaultEngine
+	}
+
 	m.Engines[request.Name] = appsearch.EngineDescription{
 		Name:          request.Name,
-		Type:          "engine",
+		Type:          engineType,
 		Language:      &request.Language,
 		DocumentCount: 0,
+		SourceEngines: request.SourceEngines,
 	}
 	return m.ListEngine(ctx, request.Name)
 }
 
+func (m *mock) CreateMetaEngine(ctx context.Context, engineName string, sourceEngines []string) (desc appsearch.EngineDescription, err error) {
+	return m.CreateEngine(ctx, appsearch.CreateEngineRequest{
+		Name:          engineName,
+		Type:          appsearch.MetaEngine,
+		SourceEngines: sourceEngines,
+	})
+}
+
+func (m *mock) AddSourceEngines(ctx context.Context, engineName string, sourceEngines []string) (desc appsearch.EngineDescription, err error) {
+	desc, err = m.ListEngine(ctx, engineName)
+	if err != nil {
+		return
+	}
+
+	desc.SourceEngines = append(desc.SourceEngines, converge.Difference(sourceEngines, desc.SourceEngines)...)
+	m.Engines[engineName] = desc
+	return desc, nil
+}
+
+func (m *mock) RemoveSourceEngines(ctx context.Context, engineName string, sourceEngines []string) (desc appsearch.EngineDescription, err error) {
+	desc, err = m.ListEngine(ctx, engineName)
+	if err != nil {
+		return
+	}
+
+	desc.SourceEngines = converge.Difference(desc.SourceEngines, sourceEngines)
+	m.Engines[engineName] = desc
+	return desc, nil
+}
+
 func (m *mock) DeleteEngine(ctx context.Context, engineName string) (err error) {
 	_, ok := m.Engines[engineName]
 	if !ok {
@@ -51,10 +88,18 @@ func (m *mock) DeleteEngine(ctx context.Context, engineName string) (err error)
 }
 
 func (m *mock) EnsureEngine(ctx context.Context, request appsearch.CreateEngineRequest, schema ...schema.Definition) (err error) {
-	_, err = m.ListEngine(ctx, request.Name)
+	engine, err := m.ListEngine(ctx, request.Name)
 
 	if errors.Is(err, appsearch.ErrEngineDoesntExist) {
 		_, err = m.CreateEngine(ctx, request)
+	} else if err == nil && request.Type == appsearch.MetaEngine {
+		err = converge.Set(engine.SourceEngines, request.SourceEngines, func(missing []string) error {
+			_, err := m.AddSourceEngines(ctx, engine.Name, missing)
+			return err
+		}, func(extra []string) error {
+			_, err := m.RemoveSourceEngines(ctx, engine.Name, extra)
+			return err
+		})
 	}
 
 	if err == nil && len(schema) > 0 {
@@ -13,6 +13,16 @@ import (
 type mock struct {
 	Engines map[string]appsearch.EngineDescription
 	Schemas map[string]schema.Definition
+	// Synonym sets by engine name and synonym set ID
+	Synonyms map[string]map[string]appsearch.SynonymSet
+	// Curations by engine name and curation ID
+	Curations map[string]map[string]appsearch.Curation
+	// Search settings by engine name (defaults are derived from schema)
+	SearchSettings map[string]appsearch.SearchSettings
+	// Logged clicks by engine name
+	Clicks map[string][]appsearch.ClickRequest
+	// API keys by name
+	Credentials map[string]appsearch.CredentialDescription
 
 	Implementation map[string]interface{}
 }
@@ -22,6 +32,11 @@ func Mock(args ...interface{}) *mock {
 	m := &mock{
 		Engines:        map[string]appsearch.EngineDescription{},
 		Schemas:        map[string]schema.Definition{},
+		Synonyms:       map[string]map[string]appsearch.SynonymSet{},
+		Curations:      map[string]map[string]appsearch.Curation{},
+		SearchSettings: map[string]appsearch.SearchSettings{},
+		Clicks:         map[string][]appsearch.ClickRequest{},
+		Credentials:    map[string]appsearch.CredentialDescription{},
 		Implementation: map[string]interface{}{},
 	}
 	for _, v := range args {
@@ -30,10 +45,20 @@ func Mock(args ...interface{}) *mock {
 			m.Engines = v
 		case map[string]schema.Definition:
 			m.Schemas = v
+		case map[string]map[string]appsearch.SynonymSet:
+			m.Synonyms = v
+		case map[string]map[string]appsearch.Curation:
+			m.Curations = v
+		case map[string]appsearch.SearchSettings:
+			m.SearchSettings = v
+		case map[string][]appsearch.ClickRequest:
+			m.Clicks = v
+		case map[string]appsearch.CredentialDescription:
+			m.Credentials = v
 		case map[string]interface{}:
 			m.Implementation = v
 		default:
-			panic(fmt.Errorf("accepted params for Mock() are only updates on Engine, Schemas or Implementations fields"))
+			panic(fmt.Errorf("accepted params for Mock() are only updates on Engine, Schemas, Synonyms, Curations, SearchSettings, Clicks, Credentials or Implementations fields"))
 		}
 	}
 	return m
@@ -75,6 +100,32 @@ func callValues(method reflect.Type, interfaces []interface{}) []reflect.Value {
 	return values
 }
 
+// Slice bounds and pagination metadata for page of total items
+func paginate(total int, page appsearch.Page) (from, to int, meta appsearch.PaginationMeta) {
+	if page.Page < 1 {
+		page.Page = 1
+	}
+	if page.Size < 1 {
+		page.Size = 25
+	}
+
+	from = (page.Page - 1) * page.Size
+	to = from + page.Size
+	if from > total {
+		from = total
+	}
+	if to > total {
+		to = total
+	}
+
+	return from, to, appsearch.PaginationMeta{
+		PageSize:     page.Size,
+		TotalPages:   (total + page.Size - 1) / page.Size,
+		CurrentPage:  page.Page,
+		TotalResults: total,
+	}
+}
+
 func interfacesOf(arg ...interface{}) []interface{} {
 	return arg
 }
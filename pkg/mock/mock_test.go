@@ -2,11 +2,14 @@ package mock
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/lithiumlabcompany/appsearch"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
 )
 
 func TestMock(t *testing.T) {
@@ -22,7 +25,7 @@ func TestMock(t *testing.T) {
 		}
 		mockResult := appsearch.EngineDescription{
 			Name:          "testEngine",
-			Type:          "engine",
+			Type:          appsearch.DefaultEngine,
 			Language:      &mockRequest.Language,
 			DocumentCount: 0,
 		}
@@ -42,4 +45,145 @@ func TestMock(t *testing.T) {
 
 		require.EqualValues(t, mockResult, res)
 	})
+	t.Run("Synonym sets in memory", func(t *testing.T) {
+		ctx := context.TODO()
+		m := Mock()
+
+		set, err := m.CreateSynonymSet(ctx, "engine", appsearch.SynonymSetRequest{
+			Synonyms: []string{"park", "reserve"},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, set.ID)
+
+		set, err = m.UpdateSynonymSet(ctx, "engine", set.ID, appsearch.SynonymSetRequest{
+			Synonyms: []string{"park"},
+		})
+		require.NoError(t, err)
+
+		sets, err := m.ListSynonymSets(ctx, "engine", appsearch.Page{})
+		require.NoError(t, err)
+		require.EqualValues(t, []appsearch.SynonymSet{set}, sets.Results)
+		require.EqualValues(t, 1, sets.Meta.Page.TotalResults)
+
+		require.NoError(t, m.DeleteSynonymSet(ctx, "engine", set.ID))
+		_, err = m.ListSynonymSet(ctx, "engine", set.ID)
+		require.ErrorIs(t, err, appsearch.ErrSynonymSetDoesntExist)
+	})
+	t.Run("Curations in memory", func(t *testing.T) {
+		ctx := context.TODO()
+		m := Mock()
+
+		curation, err := m.CreateCuration(ctx, "engine", appsearch.CurationRequest{
+			Queries:  []string{"park"},
+			Promoted: []string{"promoted-park"},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, curation.ID)
+
+		curation, err = m.UpdateCuration(ctx, "engine", curation.ID, appsearch.CurationRequest{
+			Queries: []string{"park"},
+			Hidden:  []string{"hidden-park"},
+		})
+		require.NoError(t, err)
+
+		curations, err := m.ListCurations(ctx, "engine", appsearch.Page{})
+		require.NoError(t, err)
+		require.EqualValues(t, []appsearch.Curation{curation}, curations.Results)
+
+		require.NoError(t, m.DeleteCuration(ctx, "engine", curation.ID))
+		_, err = m.ListCuration(ctx, "engine", curation.ID)
+		require.ErrorIs(t, err, appsearch.ErrCurationDoesntExist)
+	})
+	t.Run("EnsureEngine must converge source engines of meta engine", func(t *testing.T) {
+		ctx := context.TODO()
+		m := Mock()
+
+		_, err := m.CreateMetaEngine(ctx, "meta", []string{"a", "b"})
+		require.NoError(t, err)
+
+		err = m.EnsureEngine(ctx, appsearch.CreateEngineRequest{
+			Name:          "meta",
+			Type:          appsearch.MetaEngine,
+			SourceEngines: []string{"b", "c"},
+		})
+		require.NoError(t, err)
+
+		engine, err := m.ListEngine(ctx, "meta")
+		require.NoError(t, err)
+		require.EqualValues(t, appsearch.MetaEngine, engine.Type)
+		require.ElementsMatch(t, []string{"b", "c"}, engine.SourceEngines)
+	})
+	t.Run("EnsureSearchSettings must update only differing settings", func(t *testing.T) {
+		ctx := context.TODO()
+		m := Mock(map[string]schema.Definition{
+			"engine": {"id": "text", "title": "text"},
+		})
+
+		settings, err := m.ListSearchSettings(ctx, "engine")
+		require.NoError(t, err)
+		require.NoError(t, m.EnsureSearchSettings(ctx, "engine", settings))
+		require.Empty(t, m.SearchSettings)
+
+		settings.SearchFields["title"] = appsearch.FieldWithWeight{Weight: 5}
+		require.NoError(t, m.EnsureSearchSettings(ctx, "engine", settings))
+		require.EqualValues(t, settings, m.SearchSettings["engine"])
+
+		settings, err = m.ResetSearchSettings(ctx, "engine")
+		require.NoError(t, err)
+		require.EqualValues(t, 1, settings.SearchFields["title"].Weight)
+	})
+	t.Run("MultiSearchDocuments must dispatch to SearchDocuments", func(t *testing.T) {
+		ctx := context.TODO()
+		m := Mock(map[string]interface{}{
+			"SearchDocuments": func(ctx context.Context, engineName string, query appsearch.Query) (response appsearch.DocumentResponse, err error) {
+				if query.Query == "" {
+					return response, errors.New("empty query")
+				}
+				return appsearch.DocumentResponse{
+					Results: []schema.Map{{"id": query.Query}},
+				}, nil
+			},
+		})
+
+		responses, err := m.MultiSearchDocuments(ctx, "engine", []appsearch.Query{
+			{Query: "first"}, {Query: ""}, {Query: "third"},
+		})
+		require.Len(t, responses, 3)
+		require.EqualValues(t, "first", responses[0].Results[0]["id"])
+		require.EqualValues(t, "third", responses[2].Results[0]["id"])
+
+		var multiSearchErr *appsearch.MultiSearchError
+		require.ErrorAs(t, err, &multiSearchErr)
+		require.Len(t, multiSearchErr.Errors, 1)
+		require.EqualError(t, multiSearchErr.Errors[1], "empty query")
+	})
+	t.Run("Credentials in memory", func(t *testing.T) {
+		ctx := context.TODO()
+		m := Mock()
+
+		key, err := m.CreateCredential(ctx, appsearch.CredentialRequest{
+			Name:    "tenant-search",
+			Type:    appsearch.SearchKey,
+			Engines: []string{"engine"},
+		})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(key.Key, "search-"))
+
+		rotated, err := m.RotateCredential(ctx, "tenant-search")
+		require.NoError(t, err)
+		require.NotEqual(t, key.Key, rotated.Key)
+		require.NotEqual(t, key.ID, rotated.ID)
+		require.EqualValues(t, key.Engines, rotated.Engines)
+
+		_, err = m.CreateCredential(ctx, rotated.Request())
+		require.ErrorIs(t, err, appsearch.ErrCredentialAlreadyExists)
+
+		keys, err := m.ListCredentials(ctx, appsearch.Page{})
+		require.NoError(t, err)
+		require.EqualValues(t, []appsearch.CredentialDescription{rotated}, keys.Results)
+
+		require.NoError(t, m.DeleteCredential(ctx, "tenant-search"))
+		_, err = m.ListCredential(ctx, "tenant-search")
+		require.ErrorIs(t, err, appsearch.ErrCredentialDoesntExist)
+	})
 }
@@ -3,6 +3,8 @@ package mock
 import (
 	"context"
 
+	"github.com/lithiumlabcompany/appsearch"
+
 	"github.com/lithiumlabcompany/appsearch/pkg/schema"
 )
 
@@ -21,3 +23,7 @@ func (m *mock) UpdateSchema(ctx context.Context, engineName string, def schema.D
 func (m *mock) ListSchema(ctx context.Context, engineName string) (data schema.Definition, err error) {
 	return m.Schemas[engineName], nil
 }
+
+func (m *mock) PlanSchemaMigration(ctx context.Context, engineName string, desired schema.Definition) (plan appsearch.SchemaMigrationPlan, err error) {
+	return appsearch.NewSchemaMigrationPlan(m.Schemas[engineName], desired), nil
+}
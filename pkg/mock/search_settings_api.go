@@ -0,0 +1,60 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/lithiumlabcompany/appsearch"
+	"github.com/lithiumlabcompany/appsearch/internal/pkg/converge"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+func (m *mock) ListSearchSettings(ctx context.Context, engineName string) (data appsearch.SearchSettings, err error) {
+	data, ok := m.SearchSettings[engineName]
+	if !ok {
+		data = defaultSearchSettings(m.Schemas[engineName])
+	}
+	return
+}
+
+func (m *mock) UpdateSearchSettings(ctx context.Context, engineName string, settings appsearch.SearchSettings) (data appsearch.SearchSettings, err error) {
+	m.SearchSettings[engineName] = settings
+	return settings, nil
+}
+
+func (m *mock) ResetSearchSettings(ctx context.Context, engineName string) (data appsearch.SearchSettings, err error) {
+	delete(m.SearchSettings, engineName)
+	return m.ListSearchSettings(ctx, engineName)
+}
+
+func (m *mock) EnsureSearchSettings(ctx context.Context, engineName string, settings appsearch.SearchSettings) (err error) {
+	current, err := m.ListSearchSettings(ctx, engineName)
+	if err != nil {
+		return err
+	}
+
+	if settings.Precision == 0 {
+		settings.Precision = current.Precision
+	}
+
+	return converge.Value(current, settings, func(settings appsearch.SearchSettings) error {
+		_, err := m.UpdateSearchSettings(ctx, engineName, settings)
+		return err
+	})
+}
+
+// Search settings of engine without relevance tuning: every text field is searchable with equal weight
+func defaultSearchSettings(def schema.Definition) appsearch.SearchSettings {
+	settings := appsearch.SearchSettings{
+		SearchFields: appsearch.SearchFields{},
+		ResultFields: appsearch.ResultFields{},
+		Boosts:       appsearch.SearchSettingsBoosts{},
+		Precision:    2,
+	}
+	for field, fieldType := range def {
+		if fieldType == schema.TypeText {
+			settings.SearchFields[field] = appsearch.FieldWithWeight{Weight: 1}
+		}
+		settings.ResultFields[field] = appsearch.ResultField{Raw: &appsearch.RawField{}}
+	}
+	return settings
+}
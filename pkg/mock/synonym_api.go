@@ -0,0 +1,75 @@
+package mock
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/lithiumlabcompany/appsearch"
+)
+
+func (m *mock) ListSynonymSets(ctx context.Context, engineName string, page appsearch.Page) (data appsearch.SynonymSetResponse, err error) {
+	sets := synonymSetValues(m.Synonyms[engineName])
+	from, to, meta := paginate(len(sets), page)
+
+	return appsearch.SynonymSetResponse{
+		Meta:    appsearch.ResponseMeta{Page: meta},
+		Results: sets[from:to],
+	}, nil
+}
+
+func (m *mock) ListSynonymSet(ctx context.Context, engineName string, synonymSetID string) (data appsearch.SynonymSet, err error) {
+	data, ok := m.Synonyms[engineName][synonymSetID]
+	if !ok {
+		err = appsearch.ErrSynonymSetDoesntExist
+	}
+	return
+}
+
+func (m *mock) CreateSynonymSet(ctx context.Context, engineName string, request appsearch.SynonymSetRequest) (data appsearch.SynonymSet, err error) {
+	sets, ok := m.Synonyms[engineName]
+	if !ok {
+		sets = make(map[string]appsearch.SynonymSet)
+		m.Synonyms[engineName] = sets
+	}
+
+	data = appsearch.SynonymSet{
+		ID:       "syn-" + uuid.New().String(),
+		Synonyms: request.Synonyms,
+	}
+	sets[data.ID] = data
+	return data, nil
+}
+
+func (m *mock) UpdateSynonymSet(ctx context.Context, engineName string, synonymSetID string, request appsearch.SynonymSetRequest) (data appsearch.SynonymSet, err error) {
+	data, err = m.ListSynonymSet(ctx, engineName, synonymSetID)
+	if err != nil {
+		return
+	}
+
+	data.Synonyms = request.Synonyms
+	m.Synonyms[engineName][synonymSetID] = data
+	return data, nil
+}
+
+func (m *mock) DeleteSynonymSet(ctx context.Context, engineName string, synonymSetID string) (err error) {
+	_, err = m.ListSynonymSet(ctx, engineName, synonymSetID)
+	if err != nil {
+		return
+	}
+
+	delete(m.Synonyms[engineName], synonymSetID)
+	return nil
+}
+
+func synonymSetValues(sets map[string]appsearch.SynonymSet) []appsearch.SynonymSet {
+	values := make([]appsearch.SynonymSet, 0, len(sets))
+	for _, set := range sets {
+		values = append(values, set)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].ID < values[j].ID
+	})
+	return values
+}
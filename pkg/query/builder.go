@@ -0,0 +1,227 @@
+// Package query builds search queries with typed filters and validates them against schema.
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/lithiumlabcompany/appsearch"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+// Builder of appsearch.Query
+type Builder struct {
+	query   appsearch.Query
+	filters []Filter
+}
+
+// Start building query for search terms
+func New(query string) *Builder {
+	return &Builder{query: appsearch.Query{Query: query}}
+}
+
+// Add filters. Multiple filters are combined with All.
+func (b *Builder) Filter(filters ...Filter) *Builder {
+	b.filters = append(b.filters, filters...)
+	return b
+}
+
+// Add facets of field
+func (b *Builder) Facet(field string, facets ...appsearch.Facet) *Builder {
+	if b.query.Facets == nil {
+		b.query.Facets = appsearch.SearchFacets{}
+	}
+	b.query.Facets[field] = append(b.query.Facets[field], facets...)
+	return b
+}
+
+// Add value facet of field with top size values
+func (b *Builder) ValueFacet(field, name string, size int) *Builder {
+	return b.Facet(field, appsearch.Facet{
+		Type: appsearch.ValueFacet,
+		Name: name,
+		Size: size,
+	})
+}
+
+// Add range facet of field
+func (b *Builder) RangeFacet(field, name string, ranges ...appsearch.Range) *Builder {
+	return b.Facet(field, appsearch.Facet{
+		Type:   appsearch.RangeFacet,
+		Name:   name,
+		Ranges: ranges,
+	})
+}
+
+// Boost field
+func (b *Builder) Boost(field string, boost appsearch.SearchBoost) *Builder {
+	if b.query.Boosts == nil {
+		b.query.Boosts = appsearch.SearchBoosts{}
+	}
+	b.query.Boosts[field] = boost
+	return b
+}
+
+// Sort by field in direction ("asc" or "desc")
+func (b *Builder) Sort(field, direction string) *Builder {
+	if b.query.Sort == nil {
+		b.query.Sort = appsearch.Sorting{}
+	}
+	b.query.Sort[field] = direction
+	return b
+}
+
+// Group results by field
+func (b *Builder) Group(group appsearch.SearchGroup) *Builder {
+	b.query.Group = &group
+	return b
+}
+
+// Search in field with weight
+func (b *Builder) SearchField(field string, weight float32) *Builder {
+	if b.query.SearchFields == nil {
+		b.query.SearchFields = appsearch.SearchFields{}
+	}
+	b.query.SearchFields[field] = appsearch.FieldWithWeight{Weight: weight}
+	return b
+}
+
+// Return raw value of field (size 0 is unlimited)
+func (b *Builder) RawResultField(field string, size int) *Builder {
+	resultField := b.resultField(field)
+	resultField.Raw = &appsearch.RawField{Size: size}
+	b.query.ResultFields[field] = resultField
+	return b
+}
+
+// Return highlighted snippet of field (size 0 is default)
+func (b *Builder) SnippetResultField(field string, size int, fallback bool) *Builder {
+	resultField := b.resultField(field)
+	resultField.Snippet = &appsearch.SnippetField{Size: size, Fallback: fallback}
+	b.query.ResultFields[field] = resultField
+	return b
+}
+
+// Request page of size
+func (b *Builder) Page(page, size int) *Builder {
+	b.query.Page = &appsearch.Page{Page: page, Size: size}
+	return b
+}
+
+// Tag query for analytics
+func (b *Builder) Tags(tags ...string) *Builder {
+	b.query.Analytics = &appsearch.SearchAnalytics{Tags: tags}
+	return b
+}
+
+// Build query.
+// Query is validated against schema definition if specified.
+func (b *Builder) Build(def ...schema.Definition) (query appsearch.Query, err error) {
+	if len(def) > 0 {
+		if err = b.Validate(def[0]); err != nil {
+			return query, err
+		}
+	}
+
+	query = b.query
+	switch len(b.filters) {
+	case 0:
+	case 1:
+		query.Filters = b.filters[0].Map()
+	default:
+		query.Filters = All(b.filters...).Map()
+	}
+
+	return query, nil
+}
+
+// Validate query against schema definition
+func (b *Builder) Validate(def schema.Definition) error {
+	for _, filter := range b.filters {
+		if err := filter.Validate(def); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range sortedKeys(b.query.Facets) {
+		for _, facet := range b.query.Facets[field] {
+			if err := validateFacet(def, field, facet); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, field := range sortedKeys(b.query.Boosts) {
+		if err := validateBoost(def, field, b.query.Boosts[field]); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range sortedKeys(b.query.Sort) {
+		if field == "_score" {
+			continue
+		}
+		if err := def.ValidateField(field, "sort", schema.TypeText, schema.TypeNumber, schema.TypeDate); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range sortedKeys(b.query.SearchFields) {
+		if err := def.ValidateField(field, "search", schema.TypeText); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range sortedKeys(b.query.ResultFields) {
+		if _, ok := def[field]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+	}
+
+	if b.query.Group != nil {
+		if err := def.ValidateField(b.query.Group.Field, "group", schema.TypeText, schema.TypeNumber, schema.TypeDate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Builder) resultField(field string) appsearch.ResultField {
+	if b.query.ResultFields == nil {
+		b.query.ResultFields = appsearch.ResultFields{}
+	}
+	return b.query.ResultFields[field]
+}
+
+func validateFacet(def schema.Definition, field string, facet appsearch.Facet) error {
+	switch facet.Type {
+	case appsearch.RangeFacet:
+		return def.ValidateField(field, "range facet", schema.TypeNumber, schema.TypeDate, schema.TypeGeolocation)
+	default:
+		return def.ValidateField(field, "value facet", schema.TypeText, schema.TypeNumber, schema.TypeDate)
+	}
+}
+
+func validateBoost(def schema.Definition, field string, boost appsearch.SearchBoost) error {
+	switch boost.Type {
+	case appsearch.FunctionalBoost:
+		return def.ValidateField(field, "functional boost", schema.TypeNumber)
+	case appsearch.ProximityBoost:
+		return def.ValidateField(field, "proximity boost", schema.TypeNumber, schema.TypeDate, schema.TypeGeolocation)
+	default:
+		return def.ValidateField(field, "value boost", schema.TypeText, schema.TypeNumber, schema.TypeDate)
+	}
+}
+
+// Sorted keys of map with string keys
+func sortedKeys(m interface{}) []string {
+	values := reflect.ValueOf(m).MapKeys()
+	keys := make([]string, len(values))
+	for i, value := range values {
+		keys[i] = value.String()
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,101 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+func TestBuilder(t *testing.T) {
+	def := schema.Definition{
+		"id":       "text",
+		"title":    "text",
+		"states":   "text",
+		"rating":   "number",
+		"founded":  "date",
+		"location": "geolocation",
+	}
+
+	t.Run("Must build query", func(t *testing.T) {
+		query, err := New("parks").
+			Filter(
+				Any(Value("states", "Utah", "Nevada"), None(Value("title", "Closed"))),
+				Range("rating", 4, nil),
+				GeoDistance("location", "37.38, -122.08", 300, Kilometers),
+			).
+			ValueFacet("states", "top_states", 5).
+			RangeFacet("rating", "ratings", appsearch.Range{From: 0, To: 5}).
+			Boost("rating", appsearch.SearchBoost{
+				Type:     appsearch.FunctionalBoost,
+				Function: appsearch.LinearFunction,
+				Factor:   2,
+			}).
+			Sort("founded", "desc").
+			Group(appsearch.SearchGroup{Field: "states"}).
+			SearchField("title", 3).
+			RawResultField("title", 0).
+			SnippetResultField("title", 100, true).
+			Page(2, 10).
+			Tags("web").
+			Build(def)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(query)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"query": "parks",
+			"page": {"current": 2, "size": 10},
+			"sort": {"founded": "desc"},
+			"group": {"field": "states"},
+			"facets": {
+				"states": [{"type": "value", "name": "top_states", "size": 5}],
+				"rating": [{"type": "range", "name": "ratings", "ranges": [{"from": 0, "to": 5}]}]
+			},
+			"filters": {"all": [
+				{"any": [{"states": ["Utah", "Nevada"]}, {"none": [{"title": "Closed"}]}]},
+				{"rating": {"from": 4}},
+				{"location": {"center": "37.38, -122.08", "distance": 300, "unit": "km"}}
+			]},
+			"boosts": {"rating": {"type": "functional", "function": "linear", "factor": 2}},
+			"search_fields": {"title": {"weight": 3}},
+			"result_fields": {"title": {"raw": {}, "snippet": {"size": 100, "fallback": true}}},
+			"analytics": {"tags": ["web"]}
+		}`, string(data))
+	})
+
+	t.Run("Must use single filter as is", func(t *testing.T) {
+		query, err := New("").Filter(Value("states", "Utah")).Build()
+		require.NoError(t, err)
+		require.EqualValues(t, appsearch.SearchFilters{"states": "Utah"}, query.Filters)
+	})
+
+	t.Run("Must reject invalid query", func(t *testing.T) {
+		cases := map[string]struct {
+			builder *Builder
+			err     error
+		}{
+			"range filter on text": {New("").Filter(All(Range("title", 1, 2))), ErrFieldType},
+			"unknown filter field": {New("").Filter(Value("missing", 1)), ErrUnknownField},
+			"geo filter on number": {New("").Filter(GeoDistance("rating", "0,0", 1, Meters)), ErrFieldType},
+			"range facet on text":  {New("").RangeFacet("title", "titles"), ErrFieldType},
+			"search number field":  {New("").SearchField("rating", 1), ErrFieldType},
+			"functional boost text": {New("").Boost("title", appsearch.SearchBoost{
+				Type: appsearch.FunctionalBoost,
+			}), ErrFieldType},
+			"sort unknown field":   {New("").Sort("missing", "asc"), ErrUnknownField},
+			"unknown result field": {New("").RawResultField("missing", 0), ErrUnknownField},
+			"group geolocation":    {New("").Group(appsearch.SearchGroup{Field: "location"}), ErrFieldType},
+		}
+
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := c.builder.Build(def)
+				require.ErrorIs(t, err, c.err)
+			})
+		}
+	})
+}
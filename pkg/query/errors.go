@@ -0,0 +1,12 @@
+package query
+
+import (
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+var (
+	// Field is not defined in schema
+	ErrUnknownField = schema.ErrUnknownField
+	// Field type doesn't support the operation
+	ErrFieldType = schema.ErrFieldType
+)
@@ -0,0 +1,60 @@
+package query
+
+import (
+	"github.com/lithiumlabcompany/appsearch/pkg/filter"
+)
+
+// Filter of search query (see pkg/filter)
+type Filter = filter.Filter
+
+// DistanceUnit Unit of GeoDistance
+type DistanceUnit = filter.DistanceUnit
+
+const (
+	// Millimeters Distance unit
+	Millimeters = filter.Millimeters
+	// Centimeters Distance unit
+	Centimeters = filter.Centimeters
+	// Meters Distance unit
+	Meters = filter.Meters
+	// Kilometers Distance unit
+	Kilometers = filter.Kilometers
+	// Inches Distance unit
+	Inches = filter.Inches
+	// Feet Distance unit
+	Feet = filter.Feet
+	// Yards Distance unit
+	Yards = filter.Yards
+	// Miles Distance unit
+	Miles = filter.Miles
+)
+
+// All filters must match
+func All(filters ...Filter) Filter {
+	return filter.And(filters)
+}
+
+// Any of filters must match
+func Any(filters ...Filter) Filter {
+	return filter.Or(filters)
+}
+
+// None of filters must match
+func None(filters ...Filter) Filter {
+	return filter.Not(filters)
+}
+
+// Field must be equal to any of values
+func Value(field string, values ...interface{}) Filter {
+	return filter.Value{Field: field, Values: values}
+}
+
+// Field must be in range [from, to). Nil from or to leaves range open.
+func Range(field string, from, to interface{}) Filter {
+	return filter.Range{Field: field, From: from, To: to}
+}
+
+// Field must be within distance from center ("latitude, longitude")
+func GeoDistance(field, center string, distance float64, unit DistanceUnit) Filter {
+	return filter.GeoDistance{Field: field, Center: center, Distance: distance, Unit: unit}
+}
@@ -0,0 +1,180 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Struct tag overriding derived type of field (e.g. `appsearch:"geolocation"`) or skipping it (`appsearch:"-"`)
+const deriveTag = "appsearch"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Derive schema Definition from structure.
+// Field names follow encoding/json (json tag or field name) normalized with NormalizeField,
+// nested structures are flattened with underscore (_) separator same as in Normalize.
+// Strings and booleans are text, numbers are number, time.Time is date,
+// slices and pointers have type of their element and maps of strings are text.
+// Slices of structures or maps are not supported as Normalize doesn't flatten slices.
+// Type of field is overridden with `appsearch:"type"` tag, field is skipped with `appsearch:"-"`.
+// Geolocation fields must be tagged.
+func Derive(model interface{}) (def Definition, err error) {
+	modelType := reflect.TypeOf(model)
+	for modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v", ErrDeriveNotStruct, modelType)
+	}
+
+	def = make(Definition)
+	err = deriveStruct(def, "", modelType, map[reflect.Type]bool{})
+	return def, err
+}
+
+func deriveStruct(def Definition, prefix string, structType reflect.Type, visiting map[reflect.Type]bool) error {
+	if visiting[structType] {
+		return fmt.Errorf("%w: recursive type %v", ErrDeriveType, structType)
+	}
+	visiting[structType] = true
+	defer delete(visiting, structType)
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			// Unexported
+			continue
+		}
+
+		override := field.Tag.Get(deriveTag)
+		if override == "-" {
+			continue
+		}
+
+		name, tagged := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldType := indirect(field.Type)
+
+		// Untagged embedded structures are flattened to the same level by encoding/json
+		if field.Anonymous && !tagged && override == "" && fieldType.Kind() == reflect.Struct {
+			if err := deriveStruct(def, prefix, fieldType, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		key := NormalizeField(prefix + "_" + name)
+		if override != "" {
+			if _, ok := defaultValues[override]; !ok {
+				return fmt.Errorf("%w: %q of field %s", ErrDeriveTag, override, key)
+			}
+			if err := setField(def, key, override); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := deriveField(def, key, fieldType, visiting); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deriveField(def Definition, key string, fieldType reflect.Type, visiting map[reflect.Type]bool) error {
+	switch {
+	case fieldType == timeType:
+		return setField(def, key, TypeDate)
+	case fieldType == jsonNumberType:
+		return setField(def, key, TypeNumber)
+	case fieldType.Implements(jsonMarshalerType) || reflect.PtrTo(fieldType).Implements(jsonMarshalerType):
+		// Can't know what custom marshaler produces
+		return fmt.Errorf("%w: %v of field %s implements json.Marshaler, tag its type", ErrDeriveType, fieldType, key)
+	case fieldType.Implements(textMarshalerType) || reflect.PtrTo(fieldType).Implements(textMarshalerType):
+		return setField(def, key, TypeText)
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String, reflect.Bool:
+		return setField(def, key, TypeText)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return setField(def, key, TypeNumber)
+	case reflect.Slice, reflect.Array:
+		if fieldType.Elem().Kind() == reflect.Uint8 {
+			// Bytes are encoded to base64 string
+			return setField(def, key, TypeText)
+		}
+		elem := indirect(fieldType.Elem())
+		// Normalize doesn't flatten slices, so nested fields of elements would never match document
+		if elem.Kind() == reflect.Map || (elem.Kind() == reflect.Struct && !singleValueStruct(elem)) {
+			return fmt.Errorf("%w: %v of field %s has nested fields in slice", ErrDeriveType, fieldType, key)
+		}
+		return deriveField(def, key, elem, visiting)
+	case reflect.Map:
+		// Inner strings of map are collected to base key by Normalize
+		elem := indirect(fieldType.Elem())
+		if elem.Kind() == reflect.Slice {
+			elem = indirect(elem.Elem())
+		}
+		if fieldType.Key().Kind() == reflect.String && elem.Kind() == reflect.String {
+			return setField(def, key, TypeText)
+		}
+	case reflect.Struct:
+		return deriveStruct(def, key, fieldType, visiting)
+	}
+
+	return fmt.Errorf("%w: %v of field %s", ErrDeriveType, fieldType, key)
+}
+
+// Set type of field making sure fields normalized to same key don't conflict
+func setField(def Definition, key string, fieldType Type) error {
+	if previous, ok := def[key]; ok && previous != fieldType {
+		return fmt.Errorf("%w: %s is both %s and %s", ErrDeriveConflict, key, previous, fieldType)
+	}
+	def[key] = fieldType
+	return nil
+}
+
+// Name of field in JSON and whether it was set by tag
+func jsonName(field reflect.StructField) (name string, tagged bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "-", true
+	}
+	if name = strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, false
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// Struct encoded as single JSON value rather than object of fields
+func singleValueStruct(structType reflect.Type) bool {
+	return structType == timeType ||
+		structType.Implements(jsonMarshalerType) || reflect.PtrTo(structType).Implements(jsonMarshalerType) ||
+		structType.Implements(textMarshalerType) || reflect.PtrTo(structType).Implements(textMarshalerType)
+}
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type deriveBase struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type deriveLocation struct {
+	City  string `json:"city"`
+	Point string `json:"point" appsearch:"geolocation"`
+}
+
+type deriveModel struct {
+	deriveBase
+	Title    string            `json:"title"`
+	Rating   *float64          `json:"rating,omitempty"`
+	Visitors int               `json:"visitors"`
+	Open     bool              `json:"open"`
+	Tags     []string          `json:"tags"`
+	Scores   []int             `json:"scores"`
+	Location deriveLocation    `json:"location"`
+	Opened   *time.Time        `json:"opened"`
+	Names    map[string]string `json:"names"`
+	Code     int               `json:"code" appsearch:"text"`
+	Internal string            `json:"internal" appsearch:"-"`
+	Ignored  string            `json:"-"`
+	Raw      []byte
+	hidden   string
+}
+
+func TestDerive(t *testing.T) {
+	t.Run("Should derive flattened definition", func(t *testing.T) {
+		def, err := Derive(&deriveModel{})
+		require.NoError(t, err)
+
+		require.EqualValues(t, Definition{
+			"id":             TypeText,
+			"createdat":      TypeDate,
+			"title":          TypeText,
+			"rating":         TypeNumber,
+			"visitors":       TypeNumber,
+			"open":           TypeText,
+			"tags":           TypeText,
+			"scores":         TypeNumber,
+			"location_city":  TypeText,
+			"location_point": TypeGeolocation,
+			"opened":         TypeDate,
+			"names":          TypeText,
+			"code":           TypeText,
+			"raw":            TypeText,
+		}, def)
+	})
+
+	t.Run("Should match keys of ToMap", func(t *testing.T) {
+		rating := 4.5
+		model := deriveModel{Title: "Park", Rating: &rating, Location: deriveLocation{City: "Baku"}}
+
+		def, err := Derive(model)
+		require.NoError(t, err)
+
+		normalized, err := ToMap(model, def)
+		require.NoError(t, err)
+		for key := range normalized {
+			require.Contains(t, def, key)
+		}
+		require.EqualValues(t, "Baku", normalized["location_city"])
+	})
+
+	t.Run("Should fail", func(t *testing.T) {
+		_, err := Derive("text")
+		require.ErrorIs(t, err, ErrDeriveNotStruct)
+
+		_, err = Derive(struct{ Any interface{} }{})
+		require.ErrorIs(t, err, ErrDeriveType)
+
+		_, err = Derive(struct {
+			Point string `appsearch:"point"`
+		}{})
+		require.ErrorIs(t, err, ErrDeriveTag)
+
+		_, err = Derive(struct {
+			Count   int    `json:"count"`
+			Count__ string `json:"count_"`
+		}{})
+		require.ErrorIs(t, err, ErrDeriveConflict)
+
+		type item struct {
+			Name string `json:"name"`
+		}
+		_, err = Derive(struct {
+			Items []item `json:"items"`
+		}{})
+		require.ErrorIs(t, err, ErrDeriveType)
+
+		_, err = Derive(struct {
+			Labels []map[string]string `json:"labels"`
+		}{})
+		require.ErrorIs(t, err, ErrDeriveType)
+
+		type node struct {
+			Next *node `json:"next"`
+		}
+		_, err = Derive(node{})
+		require.ErrorIs(t, err, ErrDeriveType)
+	})
+}
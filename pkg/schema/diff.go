@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"sort"
+)
+
+// FieldChange Type of field in current and desired definition (empty when field is missing)
+type FieldChange struct {
+	Field   string
+	Current Type
+	Desired Type
+}
+
+// DefinitionDiff Fields of definitions classified by change, sorted by field name
+type DefinitionDiff struct {
+	// Fields missing in current definition
+	Added []FieldChange
+	// Fields of the same type in both definitions
+	Unchanged []FieldChange
+	// Fields with different type in desired definition
+	Retyped []FieldChange
+	// Fields missing in desired definition
+	Removed []FieldChange
+}
+
+// Diff current definition against desired definition
+func Diff(current, desired Definition) (diff DefinitionDiff) {
+	for _, field := range sortedFields(desired) {
+		change := FieldChange{Field: field, Current: current[field], Desired: desired[field]}
+
+		switch currentType, ok := current[field]; {
+		case !ok:
+			diff.Added = append(diff.Added, change)
+		case currentType == change.Desired:
+			diff.Unchanged = append(diff.Unchanged, change)
+		default:
+			diff.Retyped = append(diff.Retyped, change)
+		}
+	}
+
+	for _, field := range sortedFields(current) {
+		if _, ok := desired[field]; !ok {
+			diff.Removed = append(diff.Removed, FieldChange{Field: field, Current: current[field]})
+		}
+	}
+
+	return diff
+}
+
+// Whether definitions differ
+func (d DefinitionDiff) Changed() bool {
+	return len(d.Added) > 0 || len(d.Retyped) > 0 || len(d.Removed) > 0
+}
+
+func sortedFields(def Definition) []string {
+	fields := make([]string, 0, len(def))
+	for field := range def {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("Should classify fields", func(t *testing.T) {
+		diff := Diff(Definition{
+			"title":    TypeText,
+			"rating":   TypeText,
+			"location": TypeGeolocation,
+			"visitors": TypeNumber,
+		}, Definition{
+			"title":     TypeText,
+			"rating":    TypeNumber,
+			"visitors":  TypeNumber,
+			"createdat": TypeDate,
+		})
+
+		require.EqualValues(t, DefinitionDiff{
+			Added: []FieldChange{{Field: "createdat", Desired: TypeDate}},
+			Unchanged: []FieldChange{
+				{Field: "title", Current: TypeText, Desired: TypeText},
+				{Field: "visitors", Current: TypeNumber, Desired: TypeNumber},
+			},
+			Retyped: []FieldChange{{Field: "rating", Current: TypeText, Desired: TypeNumber}},
+			Removed: []FieldChange{{Field: "location", Current: TypeGeolocation}},
+		}, diff)
+		require.True(t, diff.Changed())
+	})
+
+	t.Run("Should not report changes of equal definitions", func(t *testing.T) {
+		diff := Diff(Definition{"title": TypeText}, Definition{"title": TypeText})
+		require.False(t, diff.Changed())
+	})
+}
@@ -5,10 +5,22 @@ import (
 )
 
 var (
-	// Panic in result of trying to deserialize results without { raw } values (should not happen with actual appsearch.SearchResponse)
-	ErrRawValue = errors.New("inner map has value other than { raw }")
+	// Result field has neither { raw } nor { snippet } value (should not happen with actual appsearch.SearchResponse)
+	ErrRawValue = errors.New("inner map has value other than { raw } or { snippet }")
 	// Cannot unpack normalized map to slice
 	ErrCannotUnpackSlice = errors.New("cannot Unpack map to slice. use UnpackSlice")
 	// Cannot unpack to map
 	ErrCannotInferFromMap = errors.New("cannot infer structure from map")
+	// Field is not defined in schema
+	ErrUnknownField = errors.New("field is not defined in schema")
+	// Field type doesn't support the operation
+	ErrFieldType = errors.New("operation is not supported by field type")
+	// Cannot derive schema from value other than structure
+	ErrDeriveNotStruct = errors.New("cannot derive schema from non-struct type")
+	// Cannot derive schema type from Go type (use `appsearch:"type"` tag)
+	ErrDeriveType = errors.New("cannot derive schema type")
+	// Unknown schema type in `appsearch:"type"` tag
+	ErrDeriveTag = errors.New("unknown schema type in tag")
+	// Fields are normalized to the same name with different types
+	ErrDeriveConflict = errors.New("conflicting types of field")
 )
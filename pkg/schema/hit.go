@@ -0,0 +1,69 @@
+package schema
+
+// Hit Single search result with its metadata.
+// Search results hold { raw, snippet } values of fields and "_meta" with score, engine and ID.
+type Hit struct {
+	// Document ID
+	ID string
+	// Engine of document (source engine of meta engine)
+	Engine string
+	// Relevance score
+	Score float64
+	// Raw values by field
+	Raw Map
+	// Highlighted snippets by field
+	Snippets map[string]string
+}
+
+// Parse search result into Hit.
+// Values which are not { raw, snippet } maps are stored in Raw as is.
+func ParseHit(result Map) Hit {
+	hit := Hit{
+		Raw:      make(Map, len(result)),
+		Snippets: make(map[string]string),
+	}
+
+	for field, value := range result {
+		if field == metaField {
+			meta, _ := value.(Map)
+			hit.ID, _ = meta["id"].(string)
+			hit.Engine, _ = meta["engine"].(string)
+			hit.Score = toFloat(meta["score"])
+			continue
+		}
+
+		inner, ok := value.(Map)
+		if !ok {
+			hit.Raw[field] = value
+			continue
+		}
+		if raw, ok := inner["raw"]; ok {
+			hit.Raw[field] = raw
+		}
+		if snippet, ok := inner["snippet"].(string); ok {
+			hit.Snippets[field] = snippet
+		}
+	}
+
+	return hit
+}
+
+// Unpack raw values of hit into output (see Unpack)
+func (h Hit) Unpack(output interface{}) error {
+	return Unpack(h.Raw, output)
+}
+
+func toFloat(value interface{}) float64 {
+	switch value := value.(type) {
+	case float64:
+		return value
+	case float32:
+		return float64(value)
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	default:
+		return 0
+	}
+}
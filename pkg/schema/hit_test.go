@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHit(t *testing.T) {
+	result := Map{
+		"title": Map{
+			"raw":     "Amazing park",
+			"snippet": "<em>Amazing</em> park",
+		},
+		"description": Map{"snippet": "An <em>amazing</em> view"},
+		"rating":      Map{"raw": 4.5},
+		"_meta": Map{
+			"id":     "amazing-park",
+			"engine": "parks",
+			"score":  1.5,
+		},
+	}
+
+	t.Run("ParseHit", func(t *testing.T) {
+		require.EqualValues(t, Hit{
+			ID:     "amazing-park",
+			Engine: "parks",
+			Score:  1.5,
+			Raw: Map{
+				"title":  "Amazing park",
+				"rating": 4.5,
+			},
+			Snippets: map[string]string{
+				"title":       "<em>Amazing</em> park",
+				"description": "An <em>amazing</em> view",
+			},
+		}, ParseHit(result))
+	})
+
+	t.Run("Unpack", func(t *testing.T) {
+		type model struct {
+			Title  string  `json:"title"`
+			Rating float64 `json:"rating"`
+		}
+		var output model
+		err := ParseHit(result).Unpack(&output)
+		require.NoError(t, err)
+		require.EqualValues(t, model{Title: "Amazing park", Rating: 4.5}, output)
+	})
+}
@@ -4,6 +4,24 @@ import (
 	"strings"
 )
 
+// Search result metadata field
+const metaField = "_meta"
+
+// Objectify normalized search result or document skipping its metadata
+func objectifyResult(flatMap Map) Map {
+	if _, hasMeta := flatMap[metaField]; hasMeta {
+		withoutMeta := make(Map, len(flatMap))
+		for key, value := range flatMap {
+			if key != metaField {
+				withoutMeta[key] = value
+			}
+		}
+		flatMap = withoutMeta
+	}
+
+	return objectify(flatMap, "_")
+}
+
 func objectify(flatMap Map, sep string) Map {
 	nestedMap := make(Map)
 
@@ -1,5 +1,9 @@
 package schema
 
+import (
+	"fmt"
+)
+
 type Map = map[string]interface{}
 
 // Schema definition as map[string]Type
@@ -15,3 +19,19 @@ const (
 	TypeNumber      = "number"
 	TypeGeolocation = "geolocation"
 )
+
+// Check that field is defined in schema with one of allowed types for operation
+func (d Definition) ValidateField(field, operation string, allowed ...Type) error {
+	fieldType, ok := d[field]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownField, field)
+	}
+
+	for _, t := range allowed {
+		if fieldType == t {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s on %s field %s", ErrFieldType, operation, fieldType, field)
+}
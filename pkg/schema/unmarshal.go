@@ -33,7 +33,7 @@ func UnpackSlice(results []Map, output interface{}) (err error) {
 	for i, result := range results {
 		newResult := reflect.New(valueType).Interface()
 
-		nestedMap := objectify(result, "_")
+		nestedMap := objectifyResult(result)
 		denormalizedMap, err := denormalize(nestedMap, fieldIndex, tagIndex)
 		if err == nil {
 			err = unmarshalInto(denormalizedMap, &newResult)
@@ -68,7 +68,7 @@ func Unpack(normalizedMap Map, output interface{}) (err error) {
 		return err
 	}
 
-	nestedMap := objectify(normalizedMap, "_")
+	nestedMap := objectifyResult(normalizedMap)
 	denormalizedMap, err := denormalize(nestedMap, fieldIndex, tagIndex)
 	if err == nil {
 		err = unmarshalInto(denormalizedMap, output)
@@ -98,7 +98,7 @@ func Unmarshal(data []byte, output interface{}) (err error) {
 
 // Return normalizedMap back to original form based on type-lookups on model interface
 func Denormalize(normalizedMap Map, model interface{}) (denormalizedMap Map, err error) {
-	nestedMap := objectify(normalizedMap, "_")
+	nestedMap := objectifyResult(normalizedMap)
 
 	fieldIndex, tagIndex, err := buildIndex(reflect.TypeOf(model))
 	if err != nil {
@@ -133,12 +133,11 @@ func denormalize(nestedMap Map, fieldIndex map[string]reflect.StructField, tagIn
 				}
 				continue
 			}
-			// Handle unpacking of { raw } values
-			rawValue, ok := innerMap["raw"]
-			if !ok {
-				panic(ErrRawValue)
+			// Handle unpacking of { raw } or { snippet } values
+			value, err = resultValue(innerMap)
+			if err != nil {
+				return nil, err
 			}
-			value = rawValue
 		}
 
 		if hasInnerField {
@@ -155,6 +154,17 @@ func denormalize(nestedMap Map, fieldIndex map[string]reflect.StructField, tagIn
 	return
 }
 
+// Raw value of search result field, or snippet if raw value isn't requested
+func resultValue(innerMap Map) (interface{}, error) {
+	if rawValue, ok := innerMap["raw"]; ok {
+		return rawValue, nil
+	}
+	if snippet, ok := innerMap["snippet"]; ok {
+		return snippet, nil
+	}
+	return nil, ErrRawValue
+}
+
 func decodeValue(value interface{}, valueType, fieldType reflect.Type) (interface{}, error) {
 	switch {
 	case fieldType.Kind() == reflect.Bool:
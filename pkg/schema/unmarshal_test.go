@@ -152,6 +152,33 @@ func TestUnmarshal(t *testing.T) {
 		// })
 	})
 
+	t.Run("Unpack search result", func(t *testing.T) {
+		type model struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		}
+
+		t.Run("Must use snippet without raw value and skip _meta", func(t *testing.T) {
+			var output model
+			err := Unpack(Map{
+				"title":       Map{"raw": "Amazing park", "snippet": "<em>Amazing</em> park"},
+				"description": Map{"snippet": "An <em>amazing</em> view"},
+				"_meta":       Map{"id": "amazing-park", "score": 1.5},
+			}, &output)
+			require.NoError(t, err)
+			require.EqualValues(t, model{
+				Title:       "Amazing park",
+				Description: "An <em>amazing</em> view",
+			}, output)
+		})
+
+		t.Run("Must return ErrRawValue for unknown inner map", func(t *testing.T) {
+			var output model
+			err := Unpack(Map{"title": Map{"unknown": "value"}}, &output)
+			require.ErrorIs(t, err, ErrRawValue)
+		})
+	})
+
 	t.Run("Unmarshal", func(t *testing.T) {
 		type model struct {
 			Hello string
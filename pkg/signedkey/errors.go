@@ -0,0 +1,10 @@
+package signedkey
+
+import (
+	"errors"
+)
+
+var (
+	// Search key and its name are required to sign a key
+	ErrMissingKey = errors.New("search key and key name are required")
+)
@@ -0,0 +1,65 @@
+// Package signedkey generates signed search keys.
+// Signed search key is a JWT signed with a search key, restricting every
+// search request made with it. It can be passed to appsearch.Open in place of the search key.
+package signedkey
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/lithiumlabcompany/appsearch"
+)
+
+// Restrictions applied to search requests made with signed key
+type Restrictions struct {
+	// Searchable fields with weights
+	SearchFields appsearch.SearchFields `json:"search_fields,omitempty"`
+	// Fields returned in results
+	ResultFields appsearch.ResultFields `json:"result_fields,omitempty"`
+	// Filters applied to every search
+	Filters appsearch.SearchFilters `json:"filters,omitempty"`
+	// Facets returned in every search
+	Facets appsearch.SearchFacets `json:"facets,omitempty"`
+	// Sorting of results
+	Sort appsearch.Sorting `json:"sort,omitempty"`
+	// Page size of results
+	PageSize int `json:"-"`
+}
+
+// JWT claims of signed key
+type claims struct {
+	Restrictions
+	APIKeyName string          `json:"api_key_name"`
+	Page       *appsearch.Page `json:"page,omitempty"`
+}
+
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Sign restrictions with search key of keyName
+func Sign(searchKey, keyName string, restrictions Restrictions) (signedKey string, err error) {
+	if searchKey == "" || keyName == "" {
+		return "", ErrMissingKey
+	}
+
+	payload := claims{
+		Restrictions: restrictions,
+		APIKeyName:   keyName,
+	}
+	if restrictions.PageSize > 0 {
+		payload.Page = &appsearch.Page{Size: restrictions.PageSize}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(data)
+
+	mac := hmac.New(sha256.New, []byte(searchKey))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,52 @@
+package signedkey
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("Must sign restrictions with search key", func(t *testing.T) {
+		signedKey, err := Sign("search-key", "tenant-search", Restrictions{
+			SearchFields: appsearch.SearchFields{"title": {Weight: 2}},
+			Filters:      appsearch.SearchFilters{"tenant": "acme"},
+			PageSize:     10,
+		})
+		require.NoError(t, err)
+
+		parts := strings.Split(signedKey, ".")
+		require.Len(t, parts, 3)
+
+		header, err := base64.RawURLEncoding.DecodeString(parts[0])
+		require.NoError(t, err)
+		require.JSONEq(t, `{"alg": "HS256", "typ": "JWT"}`, string(header))
+
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"api_key_name": "tenant-search",
+			"search_fields": {"title": {"weight": 2}},
+			"filters": {"tenant": "acme"},
+			"page": {"size": 10}
+		}`, string(payload))
+
+		mac := hmac.New(sha256.New, []byte("search-key"))
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		require.EqualValues(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+	})
+
+	t.Run("Must require search key and key name", func(t *testing.T) {
+		_, err := Sign("", "tenant-search", Restrictions{})
+		require.ErrorIs(t, err, ErrMissingKey)
+
+		_, err = Sign("search-key", "", Restrictions{})
+		require.ErrorIs(t, err, ErrMissingKey)
+	})
+}
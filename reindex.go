@@ -0,0 +1,193 @@
+package appsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+// ErrReindexIncomplete Source engine ran out of documents before its last page (e.g. list limit was reached)
+// or listed other page than requested
+var ErrReindexIncomplete = errors.New("reindex is incomplete")
+
+// ReindexTransform Transform document of source engine before indexing into destination engine.
+// Returning nil document skips it.
+type ReindexTransform func(document schema.Map) (schema.Map, error)
+
+// ReindexConfig Reindex configuration
+type ReindexConfig struct {
+	// Meta engine used as alias. Its source engine is swapped from source to destination engine
+	// when all documents are copied (meta engine is created if it doesn't exist).
+	// Alias is not swapped if not specified.
+	Alias string
+	// Number of documents in a single page of source engine (MaxBatchDocuments if not specified)
+	PageSize int
+	// Page of source engine to resume from (ReindexProgress.Page + 1 of interrupted Reindex)
+	StartPage int
+	// Called after every copied page
+	OnProgress func(progress ReindexProgress)
+}
+
+// ReindexProgress Progress of Reindex
+type ReindexProgress struct {
+	// Last copied page of source engine
+	Page int
+	// Total pages of source engine
+	TotalPages int
+	// Documents indexed into destination engine
+	Indexed int
+	// Documents skipped by transform
+	Skipped int
+	// Whether alias was swapped to destination engine
+	Swapped bool
+}
+
+// Reindex documents of source engine into destination engine created with newSchema.
+// Documents are listed page by page, transformed (if transform is not nil) and indexed with UpdateDocuments.
+// When all documents are copied alias meta engine is swapped to destination engine:
+// destination is added before source is removed, so alias never serves an empty engine,
+// but searches of alias return documents of both engines until source is removed.
+// On error returned progress can be used to resume with ReindexConfig.StartPage,
+// pages are indexed by document ID so copying a page again is safe.
+func Reindex(ctx context.Context, api APIClient, src, dst string, newSchema schema.Definition, transform ReindexTransform, config ...ReindexConfig) (progress ReindexProgress, err error) {
+	var cfg ReindexConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = MaxBatchDocuments
+	}
+	if cfg.StartPage <= 0 {
+		cfg.StartPage = 1
+	}
+
+	source, err := api.ListEngine(ctx, src)
+	if err != nil {
+		return
+	}
+
+	request := CreateEngineRequest{Name: dst}
+	if source.Language != nil {
+		request.Language = *source.Language
+	}
+	if err = api.EnsureEngine(ctx, request, newSchema); err != nil {
+		return
+	}
+
+	progress.Page = cfg.StartPage - 1
+	for {
+		var response DocumentResponse
+		response, err = api.ListDocuments(ctx, src, Page{Page: progress.Page + 1, Size: cfg.PageSize})
+		if err != nil {
+			return
+		}
+
+		// Copying the same page again would report success with documents missing
+		if current := response.Meta.Page.CurrentPage; current != progress.Page+1 {
+			err = fmt.Errorf("%w: requested page %d, listed page %d", ErrReindexIncomplete, progress.Page+1, current)
+			return
+		}
+
+		progress.TotalPages = response.Meta.Page.TotalPages
+		if len(response.Results) == 0 {
+			if progress.Page < progress.TotalPages {
+				err = fmt.Errorf("%w: page %d of %d is empty", ErrReindexIncomplete, progress.Page+1, progress.TotalPages)
+				return
+			}
+			break
+		}
+
+		documents := make([]schema.Map, 0, len(response.Results))
+		for _, document := range response.Results {
+			if transform != nil {
+				document, err = transform(document)
+				if err != nil {
+					return
+				}
+			}
+			if document != nil {
+				documents = append(documents, document)
+			}
+		}
+
+		if len(documents) > 0 {
+			var res []UpdateResponse
+			res, err = api.UpdateDocuments(ctx, dst, documents)
+			if err != nil {
+				return
+			}
+			for _, updated := range res {
+				if len(updated.Errors) > 0 {
+					err = fmt.Errorf("document %s: %w", updated.ID, &Error{Messages: updated.Errors})
+					return
+				}
+			}
+		}
+
+		progress.Page++
+		progress.Indexed += len(documents)
+		progress.Skipped += len(response.Results) - len(documents)
+		if cfg.OnProgress != nil {
+			cfg.OnProgress(progress)
+		}
+
+		if progress.Page >= progress.TotalPages {
+			break
+		}
+	}
+
+	if cfg.Alias != "" {
+		if err = swapSourceEngine(ctx, api, cfg.Alias, src, dst); err != nil {
+			return
+		}
+		progress.Swapped = true
+		if cfg.OnProgress != nil {
+			cfg.OnProgress(progress)
+		}
+	}
+
+	return progress, nil
+}
+
+// Replace source engine of alias meta engine keeping its other source engines.
+// Destination is added before source is removed, so until removal completes
+// alias serves both engines and searches return documents of both (duplicates included).
+// If source can't be removed, destination is removed again to restore alias.
+func swapSourceEngine(ctx context.Context, api EngineAPI, alias, src, dst string) error {
+	engine, err := api.ListEngine(ctx, alias)
+	if errors.Is(err, ErrEngineDoesntExist) {
+		_, err = api.CreateMetaEngine(ctx, alias, []string{dst})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	hasSource, hasDestination := false, false
+	for _, sourceEngine := range engine.SourceEngines {
+		hasSource = hasSource || sourceEngine == src
+		hasDestination = hasDestination || sourceEngine == dst
+	}
+
+	if !hasDestination {
+		if _, err = api.AddSourceEngines(ctx, alias, []string{dst}); err != nil {
+			return err
+		}
+	}
+	if !hasSource {
+		return nil
+	}
+
+	if _, err = api.RemoveSourceEngines(ctx, alias, []string{src}); err != nil {
+		if !hasDestination {
+			if _, rollbackErr := api.RemoveSourceEngines(ctx, alias, []string{dst}); rollbackErr != nil {
+				return fmt.Errorf("%w (alias %s still serves %s and %s: %v)", err, alias, src, dst, rollbackErr)
+			}
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package appsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch/internal/pkg/converge"
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+// In-memory engines and documents
+type stubReindexAPI struct {
+	APIClient
+	engines   map[string]EngineDescription
+	documents map[string][]schema.Map
+	failPage  int
+	// Fail removal of source engines from alias
+	failRemove bool
+}
+
+func newStubReindexAPI(total int) *stubReindexAPI {
+	documents := make([]schema.Map, total)
+	for i := range documents {
+		documents[i] = schema.Map{"id": strconv.Itoa(i), "rating": strconv.Itoa(i)}
+	}
+	return &stubReindexAPI{
+		engines:   map[string]EngineDescription{"parks-v1": {Name: "parks-v1"}},
+		documents: map[string][]schema.Map{"parks-v1": documents},
+	}
+}
+
+func (s *stubReindexAPI) ListEngine(ctx context.Context, engineName string) (EngineDescription, error) {
+	engine, ok := s.engines[engineName]
+	if !ok {
+		return engine, ErrEngineDoesntExist
+	}
+	return engine, nil
+}
+
+func (s *stubReindexAPI) EnsureEngine(ctx context.Context, request CreateEngineRequest, def ...schema.Definition) error {
+	s.engines[request.Name] = EngineDescription{Name: request.Name, Type: request.Type, SourceEngines: request.SourceEngines}
+	return nil
+}
+
+func (s *stubReindexAPI) CreateMetaEngine(ctx context.Context, engineName string, sourceEngines []string) (EngineDescription, error) {
+	s.engines[engineName] = EngineDescription{Name: engineName, Type: MetaEngine, SourceEngines: sourceEngines}
+	return s.engines[engineName], nil
+}
+
+func (s *stubReindexAPI) AddSourceEngines(ctx context.Context, engineName string, sourceEngines []string) (EngineDescription, error) {
+	engine := s.engines[engineName]
+	engine.SourceEngines = append(engine.SourceEngines, sourceEngines...)
+	s.engines[engineName] = engine
+	return engine, nil
+}
+
+func (s *stubReindexAPI) RemoveSourceEngines(ctx context.Context, engineName string, sourceEngines []string) (EngineDescription, error) {
+	engine := s.engines[engineName]
+	if s.failRemove && sourceEngines[0] == "parks-v1" {
+		return engine, errors.New("failure")
+	}
+	engine.SourceEngines = converge.Difference(engine.SourceEngines, sourceEngines)
+	s.engines[engineName] = engine
+	return engine, nil
+}
+
+func (s *stubReindexAPI) ListDocuments(ctx context.Context, engineName string, page Page) (DocumentResponse, error) {
+	if page.Page == s.failPage {
+		return DocumentResponse{}, errors.New("failure")
+	}
+
+	response := pageOfDocuments(len(s.documents[engineName]), page)
+	for i, document := range response.Results {
+		id, _ := strconv.Atoi(document["id"].(string))
+		response.Results[i] = s.documents[engineName][id]
+	}
+	return response, nil
+}
+
+func (s *stubReindexAPI) UpdateDocuments(ctx context.Context, engineName string, documents interface{}) (res []UpdateResponse, err error) {
+	for _, document := range documents.([]schema.Map) {
+		s.documents[engineName] = append(s.documents[engineName], document)
+		res = append(res, UpdateResponse{ID: document["id"].(string)})
+	}
+	return res, nil
+}
+
+func TestReindex(t *testing.T) {
+	newSchema := schema.Definition{"rating": schema.TypeNumber}
+	transform := func(document schema.Map) (schema.Map, error) {
+		rating, err := strconv.Atoi(document["rating"].(string))
+		if rating%10 == 0 {
+			return nil, err
+		}
+		return schema.Map{"id": document["id"], "rating": rating}, err
+	}
+
+	t.Run("Must copy documents and swap alias", func(t *testing.T) {
+		api := newStubReindexAPI(250)
+		api.engines["parks"] = EngineDescription{Name: "parks", Type: MetaEngine, SourceEngines: []string{"parks-v1", "other"}}
+
+		var reported []ReindexProgress
+		progress, err := Reindex(context.TODO(), api, "parks-v1", "parks-v2", newSchema, transform, ReindexConfig{
+			Alias:      "parks",
+			OnProgress: func(progress ReindexProgress) { reported = append(reported, progress) },
+		})
+		require.NoError(t, err)
+
+		require.EqualValues(t, ReindexProgress{Page: 3, TotalPages: 3, Indexed: 225, Skipped: 25, Swapped: true}, progress)
+		require.Len(t, reported, 4)
+		require.EqualValues(t, 1, reported[0].Page)
+		require.Len(t, api.documents["parks-v2"], 225)
+		require.EqualValues(t, 1, api.documents["parks-v2"][0]["rating"])
+		require.EqualValues(t, []string{"other", "parks-v2"}, api.engines["parks"].SourceEngines)
+	})
+
+	t.Run("Must create alias", func(t *testing.T) {
+		api := newStubReindexAPI(10)
+
+		progress, err := Reindex(context.TODO(), api, "parks-v1", "parks-v2", newSchema, nil, ReindexConfig{Alias: "parks"})
+		require.NoError(t, err)
+		require.True(t, progress.Swapped)
+		require.EqualValues(t, MetaEngine, api.engines["parks"].Type)
+		require.EqualValues(t, []string{"parks-v2"}, api.engines["parks"].SourceEngines)
+	})
+
+	t.Run("Must restore alias when source can't be removed", func(t *testing.T) {
+		api := newStubReindexAPI(10)
+		api.engines["parks"] = EngineDescription{Name: "parks", Type: MetaEngine, SourceEngines: []string{"parks-v1"}}
+		api.failRemove = true
+
+		progress, err := Reindex(context.TODO(), api, "parks-v1", "parks-v2", newSchema, nil, ReindexConfig{Alias: "parks"})
+		require.Error(t, err)
+		require.False(t, progress.Swapped)
+		require.EqualValues(t, []string{"parks-v1"}, api.engines["parks"].SourceEngines)
+	})
+
+	t.Run("Must resume from progress", func(t *testing.T) {
+		api := newStubReindexAPI(250)
+		api.failPage = 2
+
+		progress, err := Reindex(context.TODO(), api, "parks-v1", "parks-v2", newSchema, nil, ReindexConfig{Alias: "parks"})
+		require.Error(t, err)
+		require.EqualValues(t, 1, progress.Page)
+		require.False(t, progress.Swapped)
+		require.NotContains(t, api.engines, "parks")
+
+		api.failPage = 0
+		progress, err = Reindex(context.TODO(), api, "parks-v1", "parks-v2", newSchema, nil, ReindexConfig{
+			Alias:     "parks",
+			StartPage: progress.Page + 1,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 150, progress.Indexed)
+		require.Len(t, api.documents["parks-v2"], 250)
+		require.True(t, progress.Swapped)
+	})
+
+	t.Run("Must fail when source runs out of documents", func(t *testing.T) {
+		api := newStubReindexAPI(250)
+		api.documents["parks-v1"] = api.documents["parks-v1"][:100]
+
+		// Report more documents than can be listed
+		stub := &truncatedListAPI{stubReindexAPI: api, total: 250}
+		_, err := Reindex(context.TODO(), stub, "parks-v1", "parks-v2", newSchema, nil, ReindexConfig{Alias: "parks"})
+		require.ErrorIs(t, err, ErrReindexIncomplete)
+		require.NotContains(t, api.engines, "parks")
+	})
+
+	t.Run("Must request pages of source engine from server", func(t *testing.T) {
+		c, requests := recordingClient(t, func(r recordedRequest) interface{} {
+			switch r.Path {
+			case "engines/parks-v1/documents/list":
+				var body struct{ Page Page }
+				_ = json.Unmarshal([]byte(r.Body), &body)
+				return pageOfDocuments(25, body.Page)
+			case "engines/parks-v2/documents":
+				return []UpdateResponse{}
+			default:
+				return EngineDescription{Name: strings.TrimPrefix(r.Path, "engines/")}
+			}
+		})
+
+		progress, err := Reindex(context.TODO(), c, "parks-v1", "parks-v2", newSchema, nil, ReindexConfig{PageSize: 10})
+		require.NoError(t, err)
+		require.EqualValues(t, ReindexProgress{Page: 3, TotalPages: 3, Indexed: 25}, progress)
+
+		var pages []string
+		for _, request := range requests() {
+			if request.Path == "engines/parks-v1/documents/list" {
+				pages = append(pages, request.Body)
+			}
+		}
+		require.Len(t, pages, 3)
+		for i, page := range pages {
+			require.JSONEq(t, `{"page": {"current": `+strconv.Itoa(i+1)+`, "size": 10}}`, page)
+		}
+	})
+
+	t.Run("Must fail when other page is listed", func(t *testing.T) {
+		api := newStubReindexAPI(250)
+
+		// List first page whatever page is requested
+		stub := &firstPageListAPI{stubReindexAPI: api}
+		progress, err := Reindex(context.TODO(), stub, "parks-v1", "parks-v2", newSchema, nil, ReindexConfig{Alias: "parks"})
+		require.ErrorIs(t, err, ErrReindexIncomplete)
+		require.EqualValues(t, 1, progress.Page)
+		require.NotContains(t, api.engines, "parks")
+	})
+}
+
+type firstPageListAPI struct {
+	*stubReindexAPI
+}
+
+func (s *firstPageListAPI) ListDocuments(ctx context.Context, engineName string, page Page) (DocumentResponse, error) {
+	return s.stubReindexAPI.ListDocuments(ctx, engineName, Page{Page: 1, Size: page.Size})
+}
+
+type truncatedListAPI struct {
+	*stubReindexAPI
+	total int
+}
+
+func (s *truncatedListAPI) ListDocuments(ctx context.Context, engineName string, page Page) (DocumentResponse, error) {
+	response, err := s.stubReindexAPI.ListDocuments(ctx, engineName, page)
+	response.Meta.Page.TotalPages = (s.total + page.Size - 1) / page.Size
+	return response, err
+}
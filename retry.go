@@ -0,0 +1,157 @@
+package appsearch
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy Retry policy of failed requests.
+// Requests are retried on 429, 502, 503 and 504 responses and network errors
+// with exponential backoff and full jitter, Retry-After header is honored
+// unless it is longer than MaxBackoff (then error is returned without waiting).
+// Only idempotent requests are retried: GET, PUT and DELETE, searches,
+// patches of documents (which always carry ID's) and optionally updates of documents.
+type RetryPolicy struct {
+	// Maximum attempts of a request including the first one (retries are disabled if less than 2)
+	MaxAttempts int
+	// Backoff before first retry, doubled on every next retry
+	MinBackoff time.Duration
+	// Maximum backoff and Retry-After
+	MaxBackoff time.Duration
+	// Retry UpdateDocuments. Safe only if all documents carry ID's,
+	// otherwise retried documents are indexed again with new auto-generated ID's.
+	RetryDocumentWrites bool
+}
+
+// DefaultRetryPolicy Retry policy of Open (use WithRetry to change it)
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+	}
+}
+
+// Kind of endpoint by effect of request
+type endpointKind int
+
+const (
+	// Reads and searches
+	readEndpoint endpointKind = iota
+	// Writes of engines, schema and other resources
+	writeEndpoint
+	// Writes of documents
+	documentWriteEndpoint
+)
+
+// Searches sent with POST which don't change anything
+var readPostEndpoints = []string{"/search", "/multi_search", "/query_suggestion"}
+
+// Classify endpoint by method and URL format of Call
+func classifyEndpoint(method, urlFormat string) endpointKind {
+	switch {
+	case method == http.MethodGet || method == http.MethodHead:
+		return readEndpoint
+	case method == http.MethodPost && hasAnySuffix(urlFormat, readPostEndpoints):
+		return readEndpoint
+	case strings.HasSuffix(urlFormat, "/documents") && method != http.MethodDelete:
+		return documentWriteEndpoint
+	default:
+		return writeEndpoint
+	}
+}
+
+func hasAnySuffix(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether request may be sent again without duplicating its effect
+func (p RetryPolicy) retryable(method, urlFormat string) bool {
+	switch classifyEndpoint(method, urlFormat) {
+	case readEndpoint:
+		return true
+	case documentWriteEndpoint:
+		return method == http.MethodPatch || p.RetryDocumentWrites
+	default:
+		return method == http.MethodPut || method == http.MethodDelete
+	}
+}
+
+// Whether response status or network error is transient
+func retryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// Backoff before retry following attempt (starting from 1).
+// Retry-After overrides backoff if specified (see allowsRetryAfter).
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	backoff := p.MaxBackoff
+	if shift := attempt - 1; shift < 32 && p.MinBackoff<<shift < p.MaxBackoff {
+		backoff = p.MinBackoff << shift
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	// Full jitter
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// Whether Retry-After is short enough to wait for
+func (p RetryPolicy) allowsRetryAfter(retryAfter time.Duration) bool {
+	return retryAfter <= p.MaxBackoff
+}
+
+// Parse Retry-After header given in seconds or as HTTP date
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// Wait for backoff or until context is done
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Whether error of request is a network error (and not cancellation or decoding error)
+func networkError(ctx context.Context, err error) bool {
+	var urlErr *url.Error
+	return ctx.Err() == nil && errors.As(err, &urlErr) &&
+		!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
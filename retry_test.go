@@ -0,0 +1,145 @@
+package appsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// Client of server responding with statuses in order (last status is repeated)
+func retryingClient(t *testing.T, policy RetryPolicy, header http.Header, statuses ...int) (*client, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&requests, 1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statuses[i])
+		if statuses[i] >= 400 {
+			_, _ = w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/documents") {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return &client{Client: resty.New().SetHostURL(server.URL), retry: policy}, &requests
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	ctx := context.TODO()
+
+	t.Run("Must retry transient failures", func(t *testing.T) {
+		c, requests := retryingClient(t, policy, nil, 503, 429, 200)
+		_, err := c.ListEngine(ctx, "engine")
+		require.NoError(t, err)
+		require.EqualValues(t, 3, *requests)
+	})
+
+	t.Run("Must expose attempts on error", func(t *testing.T) {
+		c, requests := retryingClient(t, policy, nil, 502)
+		_, err := c.SearchDocuments(ctx, "engine", Query{})
+		require.IsType(t, &Error{}, err)
+		require.EqualValues(t, 3, err.(*Error).Attempts)
+		require.EqualValues(t, 502, err.(*Error).StatusCode)
+		require.EqualValues(t, 3, *requests)
+	})
+
+	t.Run("Must not retry other failures", func(t *testing.T) {
+		c, requests := retryingClient(t, policy, nil, 400)
+		_, err := c.ListEngine(ctx, "engine")
+		require.Error(t, err)
+		require.EqualValues(t, 1, err.(*Error).Attempts)
+		require.EqualValues(t, 1, *requests)
+	})
+
+	t.Run("Must not retry unsafe writes", func(t *testing.T) {
+		c, requests := retryingClient(t, policy, nil, 503, 200)
+		err := c.LogClickthrough(ctx, "engine", ClickRequest{})
+		require.Error(t, err)
+		require.EqualValues(t, 1, *requests)
+
+		c, requests = retryingClient(t, policy, nil, 503, 200)
+		_, err = c.UpdateDocuments(ctx, "engine", []m{{"id": "1"}})
+		require.Error(t, err)
+		require.EqualValues(t, 1, *requests)
+	})
+
+	t.Run("Must retry document writes if allowed", func(t *testing.T) {
+		policy := policy
+		policy.RetryDocumentWrites = true
+		c, requests := retryingClient(t, policy, nil, 503, 200)
+		_, err := c.UpdateDocuments(ctx, "engine", []m{{"id": "1"}})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, *requests)
+	})
+
+	t.Run("Must not wait for Retry-After longer than MaxBackoff", func(t *testing.T) {
+		c, requests := retryingClient(t, policy, http.Header{"Retry-After": {"3600"}}, 429, 200)
+		_, err := c.ListEngine(ctx, "engine")
+		require.ErrorIs(t, err, ErrRateLimited)
+		require.EqualValues(t, 1, *requests)
+	})
+
+	t.Run("Must stop waiting when context is done", func(t *testing.T) {
+		policy := policy
+		policy.MaxBackoff = time.Minute
+		c, requests := retryingClient(t, policy, http.Header{"Retry-After": {"10"}}, 429)
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := c.ListEngine(ctx, "engine")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.EqualValues(t, 1, *requests)
+	})
+
+	t.Run("Must retry network errors", func(t *testing.T) {
+		c := &client{Client: resty.New().SetHostURL("http://127.0.0.1:1"), retry: policy}
+		_, err := c.ListEngine(ctx, "engine")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "after 3 attempts")
+	})
+
+	t.Run("classifyEndpoint", func(t *testing.T) {
+		require.Equal(t, readEndpoint, classifyEndpoint(http.MethodGet, "engines/%s/documents"))
+		require.Equal(t, readEndpoint, classifyEndpoint(http.MethodPost, "engines/%s/search"))
+		require.Equal(t, readEndpoint, classifyEndpoint(http.MethodPost, "engines/%s/multi_search"))
+		require.Equal(t, documentWriteEndpoint, classifyEndpoint(http.MethodPost, "engines/%s/documents"))
+		require.Equal(t, documentWriteEndpoint, classifyEndpoint(http.MethodPatch, "engines/%s/documents"))
+		require.Equal(t, writeEndpoint, classifyEndpoint(http.MethodDelete, "engines/%s/documents"))
+		require.Equal(t, writeEndpoint, classifyEndpoint(http.MethodPost, "engines/%s/schema"))
+	})
+
+	t.Run("parseRetryAfter", func(t *testing.T) {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, 2*time.Second, parseRetryAfter("2", now))
+		require.Equal(t, 30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+		require.Zero(t, parseRetryAfter("", now))
+		require.Zero(t, parseRetryAfter("soon", now))
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 4 * time.Second}
+		for attempt := 1; attempt < 100; attempt++ {
+			require.LessOrEqual(t, policy.backoff(attempt, 0), 4*time.Second)
+		}
+		require.LessOrEqual(t, policy.backoff(1, 0), time.Second)
+		require.Equal(t, time.Minute, policy.backoff(1, time.Minute))
+	})
+}
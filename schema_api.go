@@ -20,14 +20,43 @@ func (c *client) ListSchema(ctx context.Context, engineName string) (data schema
 
 // Update schema definition by engineName
 func (c *client) UpdateSchema(ctx context.Context, engineName string, def schema.Definition) (err error) {
-	schemaDefinition := make(schema.Definition)
+	err = c.Call(ctx, withoutID(def), nil, http.MethodPost, "engines/%s/schema", engineName)
+
+	return err
+}
+
+// Plan migration of engine schema to desired definition
+func (c *client) PlanSchemaMigration(ctx context.Context, engineName string, desired schema.Definition) (plan SchemaMigrationPlan, err error) {
+	current, err := c.ListSchema(ctx, engineName)
+	if err != nil {
+		return
+	}
+
+	return NewSchemaMigrationPlan(current, desired), nil
+}
+
+// Plan migration from current to desired schema definition.
+// "id" field is ignored as it can't be changed.
+func NewSchemaMigrationPlan(current, desired schema.Definition) (plan SchemaMigrationPlan) {
+	plan.Diff = schema.Diff(withoutID(current), withoutID(desired))
+
+	plan.InPlace = make(schema.Definition, len(plan.Diff.Added))
+	for _, change := range plan.Diff.Added {
+		plan.InPlace[change.Field] = change.Desired
+	}
+
+	plan.Reindex = append(plan.Reindex, plan.Diff.Retyped...)
+	plan.Reindex = append(plan.Reindex, plan.Diff.Removed...)
+
+	return plan
+}
+
+func withoutID(def schema.Definition) schema.Definition {
+	withoutID := make(schema.Definition, len(def))
 	for field, fieldType := range def {
 		if field != "id" {
-			schemaDefinition[field] = fieldType
+			withoutID[field] = fieldType
 		}
 	}
-
-	err = c.Call(ctx, schemaDefinition, nil, http.MethodPost, "engines/%s/schema", engineName)
-
-	return err
+	return withoutID
 }
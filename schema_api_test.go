@@ -46,4 +46,42 @@ func TestSchemaAPI(t *testing.T) {
 		require.NotEmpty(t, def)
 		require.EqualValues(t, schema, def)
 	})
+	t.Run("PlanSchemaMigration", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		err := c.UpdateSchema(ctx, engine.Name, schema2.Definition{"foo": "text"})
+		require.NoError(t, err)
+
+		plan, err := c.PlanSchemaMigration(ctx, engine.Name, schema2.Definition{"foo": "number", "bar": "date"})
+		require.NoError(t, err)
+		require.EqualValues(t, schema2.Definition{"bar": "date"}, plan.InPlace)
+		require.EqualValues(t, []schema2.FieldChange{{Field: "foo", Current: "text", Desired: "number"}}, plan.Reindex)
+	})
+}
+
+func TestSchemaMigrationPlan(t *testing.T) {
+	t.Run("Must apply added fields in place", func(t *testing.T) {
+		plan := NewSchemaMigrationPlan(
+			schema2.Definition{"id": "text", "title": "text"},
+			schema2.Definition{"title": "text", "rating": "number"},
+		)
+		require.EqualValues(t, schema2.Definition{"rating": "number"}, plan.InPlace)
+		require.Empty(t, plan.Diff.Removed)
+		require.False(t, plan.RequiresReindex())
+	})
+
+	t.Run("Must reindex retyped and removed fields", func(t *testing.T) {
+		plan := NewSchemaMigrationPlan(
+			schema2.Definition{"id": "text", "title": "text", "rating": "text"},
+			schema2.Definition{"id": "text", "rating": "number"},
+		)
+		require.Empty(t, plan.InPlace)
+		require.EqualValues(t, []schema2.FieldChange{
+			{Field: "rating", Current: "text", Desired: "number"},
+			{Field: "title", Current: "text"},
+		}, plan.Reindex)
+		require.True(t, plan.RequiresReindex())
+	})
 }
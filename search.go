@@ -0,0 +1,64 @@
+package appsearch
+
+import (
+	"context"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+// ResultMeta Metadata of a single search result
+type ResultMeta struct {
+	ID     string  `json:"id"`
+	Engine string  `json:"engine"`
+	Score  float64 `json:"score"`
+}
+
+// TypedResult Search result unpacked into T
+type TypedResult[T any] struct {
+	Document T
+	Meta     ResultMeta
+	// Highlighted snippets by field
+	Snippets map[string]string
+}
+
+// TypedResponse Search response with results unpacked into T
+type TypedResponse[T any] struct {
+	Meta    ResponseMeta
+	Facets  FacetResultMap
+	Results []TypedResult[T]
+}
+
+// Search documents by query and unpack results into T via schema.Hit
+func Search[T any](ctx context.Context, api DocumentAPI, engineName string, query Query) (response TypedResponse[T], err error) {
+	search, err := api.SearchDocuments(ctx, engineName, query)
+	if err != nil {
+		return response, err
+	}
+
+	return UnpackResponse[T](search)
+}
+
+// Unpack results of search response into T via schema.Hit
+func UnpackResponse[T any](search DocumentResponse) (response TypedResponse[T], err error) {
+	response = TypedResponse[T]{
+		Meta:    search.Meta,
+		Facets:  search.Facets,
+		Results: make([]TypedResult[T], len(search.Results)),
+	}
+
+	for i, result := range search.Results {
+		hit := schema.ParseHit(result)
+
+		response.Results[i].Meta = ResultMeta{
+			ID:     hit.ID,
+			Engine: hit.Engine,
+			Score:  hit.Score,
+		}
+		response.Results[i].Snippets = hit.Snippets
+		if err = hit.Unpack(&response.Results[i].Document); err != nil {
+			return response, err
+		}
+	}
+
+	return response, nil
+}
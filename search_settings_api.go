@@ -0,0 +1,47 @@
+package appsearch
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/lithiumlabcompany/appsearch/internal/pkg/converge"
+)
+
+// List search settings of engine
+func (c *client) ListSearchSettings(ctx context.Context, engineName string) (data SearchSettings, err error) {
+	err = c.Call(ctx, nil, &data, http.MethodGet, "engines/%s/search_settings", engineName)
+
+	return data, err
+}
+
+// Update (replace) search settings of engine
+func (c *client) UpdateSearchSettings(ctx context.Context, engineName string, settings SearchSettings) (data SearchSettings, err error) {
+	err = c.Call(ctx, settings, &data, http.MethodPut, "engines/%s/search_settings", engineName)
+
+	return data, err
+}
+
+// Reset search settings of engine to defaults
+func (c *client) ResetSearchSettings(ctx context.Context, engineName string) (data SearchSettings, err error) {
+	err = c.Call(ctx, nil, &data, http.MethodPost, "engines/%s/search_settings/reset", engineName)
+
+	return data, err
+}
+
+// Update search settings only if they differ from current.
+// Precision is preserved if not specified.
+func (c *client) EnsureSearchSettings(ctx context.Context, engineName string, settings SearchSettings) (err error) {
+	current, err := c.ListSearchSettings(ctx, engineName)
+	if err != nil {
+		return err
+	}
+
+	if settings.Precision == 0 {
+		settings.Precision = current.Precision
+	}
+
+	return converge.Value(current, settings, func(settings SearchSettings) error {
+		_, err := c.UpdateSearchSettings(ctx, engineName, settings)
+		return err
+	})
+}
@@ -0,0 +1,94 @@
+package appsearch
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	schema2 "github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+func TestSearchSettingsAPI(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	c, err := Open(os.Getenv("APPSEARCH"))
+	require.NoError(t, err)
+
+	createTunedEngine := func() EngineDescription {
+		engine := createRandomEngine(c)
+		exit(c.UpdateSchema(ctx, engine.Name, schema2.Definition{
+			"title":  "text",
+			"rating": "number",
+		}))
+		return engine
+	}
+
+	t.Run("Must update and reset search settings", func(t *testing.T) {
+		t.Parallel()
+		engine := createTunedEngine()
+		defer deleteEngine(c, engine)
+
+		settings, err := c.ListSearchSettings(ctx, engine.Name)
+		require.NoError(t, err)
+		require.Contains(t, settings.SearchFields, "title")
+
+		settings.SearchFields["title"] = FieldWithWeight{Weight: 5}
+		updated, err := c.UpdateSearchSettings(ctx, engine.Name, settings)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, updated.SearchFields["title"].Weight)
+
+		reset, err := c.ResetSearchSettings(ctx, engine.Name)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, reset.SearchFields["title"].Weight)
+	})
+
+	t.Run("Must ensure search settings", func(t *testing.T) {
+		t.Parallel()
+		engine := createTunedEngine()
+		defer deleteEngine(c, engine)
+
+		settings, err := c.ListSearchSettings(ctx, engine.Name)
+		require.NoError(t, err)
+
+		settings.SearchFields["title"] = FieldWithWeight{Weight: 3}
+		err = c.EnsureSearchSettings(ctx, engine.Name, settings)
+		require.NoError(t, err)
+
+		current, err := c.ListSearchSettings(ctx, engine.Name)
+		require.NoError(t, err)
+		require.True(t, settings.Equal(current))
+	})
+}
+
+func TestSearchSettings(t *testing.T) {
+	t.Run("Must equal settings decoded from API response", func(t *testing.T) {
+		var decoded SearchSettings
+		err := json.Unmarshal([]byte(`{
+			"search_fields": {"title": {"weight": 3}},
+			"result_fields": {},
+			"boosts": {
+				"visitors": [{"type": "value", "value": [1, 2], "factor": 2}],
+				"states": [{"type": "value", "value": ["Utah", "Nevada"], "factor": 1.5}]
+			},
+			"precision": 2
+		}`), &decoded)
+		require.NoError(t, err)
+
+		settings := SearchSettings{
+			SearchFields: SearchFields{"title": {Weight: 3}},
+			Boosts: SearchSettingsBoosts{
+				"visitors": {{Type: "value", Value: []int{1, 2}, Factor: 2}},
+				"states":   {{Type: "value", Value: []string{"Utah", "Nevada"}, Factor: 1.5}},
+			},
+			Precision: 2,
+		}
+		require.True(t, settings.Equal(decoded))
+
+		settings.Boosts["visitors"][0].Value = []int{1, 3}
+		require.False(t, settings.Equal(decoded))
+	})
+}
@@ -0,0 +1,51 @@
+package appsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lithiumlabcompany/appsearch/pkg/schema"
+)
+
+func TestSearch(t *testing.T) {
+	type park struct {
+		Title  string  `json:"title"`
+		Rating float64 `json:"rating"`
+	}
+
+	api := stubDocumentAPI{search: func(ctx context.Context, engineName string, query Query) (DocumentResponse, error) {
+		return DocumentResponse{
+			Meta: ResponseMeta{RequestID: "request"},
+			Facets: FacetResultMap{
+				"state": {{Type: ValueFacet, Data: []FacetData{{Value: "Utah", Count: 1}}}},
+			},
+			Results: []schema.Map{{
+				"title": schema.Map{
+					"raw":     "Amazing park",
+					"snippet": "<em>Amazing</em> park",
+				},
+				"rating": schema.Map{"raw": 4.5},
+				"_meta": schema.Map{
+					"id":     "amazing-park",
+					"engine": "parks",
+					"score":  1.5,
+				},
+			}},
+		}, nil
+	}}
+
+	t.Run("Must return typed results with meta, facets and snippets", func(t *testing.T) {
+		response, err := Search[park](context.TODO(), api, "parks", Query{Query: "amazing"})
+		require.NoError(t, err)
+
+		require.EqualValues(t, "request", response.Meta.RequestID)
+		require.Len(t, response.Facets["state"], 1)
+		require.EqualValues(t, []TypedResult[park]{{
+			Document: park{Title: "Amazing park", Rating: 4.5},
+			Meta:     ResultMeta{ID: "amazing-park", Engine: "parks", Score: 1.5},
+			Snippets: map[string]string{"title": "<em>Amazing</em> park"},
+		}}, response.Results)
+	})
+}
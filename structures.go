@@ -1,7 +1,11 @@
 package appsearch
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/lithiumlabcompany/appsearch/pkg/schema"
@@ -55,18 +59,106 @@ type EngineResponse struct {
 	Results []EngineDescription `json:"results"`
 }
 
+// EngineType Engine type
+type EngineType = string
+
+const (
+	// DefaultEngine Engine type
+	DefaultEngine EngineType = "default"
+	// MetaEngine Engine type
+	MetaEngine EngineType = "meta"
+)
+
 // CreateEngineRequest Request for CreateEngine
 type CreateEngineRequest struct {
 	Name     string `json:"name"`
 	Language string `json:"language,omitempty"`
+	// Engine type (DefaultEngine if not specified)
+	Type EngineType `json:"type,omitempty"`
+	// Source engines of MetaEngine
+	SourceEngines []string `json:"source_engines,omitempty"`
 }
 
 // EngineDescription Engine description
 type EngineDescription struct {
-	Name          string  `json:"name"`
-	Type          string  `json:"type"`
-	Language      *string `json:"language"`
-	DocumentCount int     `json:"document_count"`
+	Name          string     `json:"name"`
+	Type          EngineType `json:"type"`
+	Language      *string    `json:"language"`
+	DocumentCount int        `json:"document_count"`
+	// Source engines of MetaEngine
+	SourceEngines []string `json:"source_engines,omitempty"`
+}
+
+// SchemaMigrationPlan Changes required to migrate engine schema to desired definition
+type SchemaMigrationPlan struct {
+	// Diff of current and desired definition ("id" excluded)
+	Diff schema.DefinitionDiff
+	// Added fields applicable in place with UpdateSchema
+	InPlace schema.Definition
+	// Retyped and removed fields which require reindex into new engine
+	Reindex []schema.FieldChange
+}
+
+// RequiresReindex Whether schema can't be migrated in place
+func (p SchemaMigrationPlan) RequiresReindex() bool {
+	return len(p.Reindex) > 0
+}
+
+// SynonymSet Synonym set
+type SynonymSet struct {
+	ID       string   `json:"id"`
+	Synonyms []string `json:"synonyms"`
+}
+
+// SynonymSetRequest Request for CreateSynonymSet or UpdateSynonymSet
+type SynonymSetRequest struct {
+	Synonyms []string `json:"synonyms"`
+}
+
+// SynonymSetResponse ListSynonymSets response
+type SynonymSetResponse struct {
+	Meta    ResponseMeta `json:"meta"`
+	Results []SynonymSet `json:"results"`
+}
+
+// CurationSuggestion Suggestion metadata attached to a curation
+type CurationSuggestion struct {
+	Status    string   `json:"status"`
+	Operation string   `json:"operation"`
+	UpdatedAt string   `json:"updated_at"`
+	Promoted  []string `json:"promoted"`
+}
+
+// Curation Curation of queries with promoted and hidden documents
+type Curation struct {
+	ID         string              `json:"id"`
+	Queries    []string            `json:"queries"`
+	Promoted   []string            `json:"promoted"`
+	Hidden     []string            `json:"hidden"`
+	Suggestion *CurationSuggestion `json:"suggestion,omitempty"`
+}
+
+// CurationRequest Request for CreateCuration or UpdateCuration
+type CurationRequest struct {
+	Queries  []string `json:"queries"`
+	Promoted []string `json:"promoted,omitempty"`
+	Hidden   []string `json:"hidden,omitempty"`
+}
+
+// Curation Curation with ID created or updated by request
+func (r CurationRequest) Curation(id string) Curation {
+	return Curation{
+		ID:       id,
+		Queries:  r.Queries,
+		Promoted: r.Promoted,
+		Hidden:   r.Hidden,
+	}
+}
+
+// CurationResponse ListCurations response
+type CurationResponse struct {
+	Meta    ResponseMeta `json:"meta"`
+	Results []Curation   `json:"results"`
 }
 
 // Sorting options
@@ -96,7 +188,7 @@ type Range struct {
 	To   interface{} `json:"to"`
 }
 
-// SearchFilters
+// SearchFilters Search filters as raw map
 type SearchFilters = schema.Map
 
 // Facet
@@ -213,8 +305,54 @@ type ResultField struct {
 // Map of result field specifications
 type ResultFields = map[string]ResultField
 
+// Search boosts as stored in engine search settings
+type SearchSettingsBoosts = map[string][]SearchBoost
+
+// Engine search settings (relevance tuning)
+type SearchSettings struct {
+	// Search fields with weights
+	SearchFields SearchFields `json:"search_fields"`
+	// Result fields
+	ResultFields ResultFields `json:"result_fields"`
+	// Search boosts
+	Boosts SearchSettingsBoosts `json:"boosts"`
+	// Precision tuning (1-11)
+	Precision int `json:"precision,omitempty"`
+}
+
+// Equal reports whether settings are equal treating nil and empty maps as equal.
+// Settings are compared in JSON form, so boost values given as e.g. int or []string
+// are equal to float64 or []interface{} decoded from API response.
+func (s SearchSettings) Equal(other SearchSettings) bool {
+	a, errA := jsonValue(s.normalized())
+	b, errB := jsonValue(other.normalized())
+	return errA == nil && errB == nil && reflect.DeepEqual(a, b)
+}
+
+// Value decoded from JSON of input
+func jsonValue(input interface{}) (value interface{}, err error) {
+	data, err := json.Marshal(input)
+	if err == nil {
+		err = json.Unmarshal(data, &value)
+	}
+	return value, err
+}
+
+func (s SearchSettings) normalized() SearchSettings {
+	if s.SearchFields == nil {
+		s.SearchFields = SearchFields{}
+	}
+	if s.ResultFields == nil {
+		s.ResultFields = ResultFields{}
+	}
+	if s.Boosts == nil {
+		s.Boosts = SearchSettingsBoosts{}
+	}
+	return s
+}
+
 // Search query structure
-// TODO: query builder
+// Use query.Builder (pkg/query) to build queries validated against schema
 type Query struct {
 	// Lucene query
 	Query string `json:"query"`
@@ -226,7 +364,8 @@ type Query struct {
 	Group *SearchGroup `json:"group,omitempty"`
 	// Search facets
 	Facets SearchFacets `json:"facets,omitempty"`
-	// Search filters
+	// Search filters.
+	// Typed filter tree of pkg/filter must be converted with its Map method: filter.And{...}.Map()
 	Filters SearchFilters `json:"filters,omitempty"`
 	// Search boosts
 	Boosts SearchBoosts `json:"boosts,omitempty"`
@@ -245,11 +384,246 @@ type DocumentResponse struct {
 	Results []schema.Map   `json:"results"`
 }
 
+// SuggestionDocuments Document fields used for query suggestions
+type SuggestionDocuments struct {
+	Fields []string `json:"fields,omitempty"`
+}
+
+// SuggestionTypes Types of query suggestions
+type SuggestionTypes struct {
+	Documents *SuggestionDocuments `json:"documents,omitempty"`
+}
+
+// SuggestionRequest Request for QuerySuggestion
+type SuggestionRequest struct {
+	// Partial query to suggest completions for
+	Query string `json:"query"`
+	// Types of suggestions
+	Types *SuggestionTypes `json:"types,omitempty"`
+	// Number of suggestions
+	Size int `json:"size,omitempty"`
+}
+
+// Suggestion Single query suggestion
+type Suggestion struct {
+	Suggestion string `json:"suggestion"`
+}
+
+// SuggestionResults Query suggestions by type
+type SuggestionResults struct {
+	Documents []Suggestion `json:"documents"`
+}
+
+// SuggestionResponse QuerySuggestion response
+type SuggestionResponse struct {
+	Meta    ResponseMeta      `json:"meta"`
+	Results SuggestionResults `json:"results"`
+}
+
+// ClickRequest Request for LogClickthrough
+type ClickRequest struct {
+	// Query that produced clicked document
+	Query string `json:"query"`
+	// Clicked document ID
+	DocumentID string `json:"document_id"`
+	// Request ID from ResponseMeta of search
+	RequestID string `json:"request_id,omitempty"`
+	// Analytics tags
+	Tags []string `json:"tags,omitempty"`
+}
+
+// AnalyticsInterval Interval of analytics counts
+type AnalyticsInterval = string
+
+const (
+	// HourInterval Analytics interval
+	HourInterval AnalyticsInterval = "hour"
+	// DayInterval Analytics interval
+	DayInterval AnalyticsInterval = "day"
+)
+
+// AnalyticsFilters Filters of analytics requests
+type AnalyticsFilters struct {
+	// Date range (dates are time.Time or RFC3339 strings)
+	Date *Range
+	// Analytics tags
+	Tags []string
+	// Only queries with (true) or without (false) results
+	Results *bool
+	// Only queries with (true) or without (false) clicks
+	Clicks *bool
+}
+
+// MarshalJSON Combine filters with "all"
+func (f AnalyticsFilters) MarshalJSON() ([]byte, error) {
+	all := make([]m, 0, 4)
+
+	if f.Date != nil {
+		date := make(m)
+		if f.Date.From != nil {
+			date["from"] = f.Date.From
+		}
+		if f.Date.To != nil {
+			date["to"] = f.Date.To
+		}
+		all = append(all, m{"date": date})
+	}
+	if len(f.Tags) > 0 {
+		all = append(all, m{"tag": f.Tags})
+	}
+	if f.Results != nil {
+		all = append(all, m{"results": *f.Results})
+	}
+	if f.Clicks != nil {
+		all = append(all, m{"clicks": *f.Clicks})
+	}
+
+	return json.Marshal(m{"all": all})
+}
+
+// AnalyticsCountsRequest Request for AnalyticsCounts
+type AnalyticsCountsRequest struct {
+	Filters  *AnalyticsFilters `json:"filters,omitempty"`
+	Interval AnalyticsInterval `json:"interval,omitempty"`
+}
+
+// AnalyticsCount Number of queries and clicks in interval
+type AnalyticsCount struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Queries int    `json:"queries"`
+	Clicks  int    `json:"clicks"`
+}
+
+// AnalyticsCountsResponse AnalyticsCounts response
+type AnalyticsCountsResponse struct {
+	Results []AnalyticsCount `json:"results"`
+}
+
+// TopQueriesRequest Request for TopQueries
+type TopQueriesRequest struct {
+	Filters *AnalyticsFilters `json:"filters,omitempty"`
+	Page    *Page             `json:"page,omitempty"`
+}
+
+// QueryAnalytics Analytics of single query
+type QueryAnalytics struct {
+	Term    string `json:"term"`
+	Queries int    `json:"queries"`
+	Clicks  int    `json:"clicks"`
+}
+
+// TopQueriesResponse TopQueries response
+type TopQueriesResponse struct {
+	Meta    ResponseMeta     `json:"meta"`
+	Results []QueryAnalytics `json:"results"`
+}
+
+// TopClicksRequest Request for TopClicks
+type TopClicksRequest struct {
+	// Only clicks of query (all clicks if empty)
+	Query   string            `json:"query,omitempty"`
+	Filters *AnalyticsFilters `json:"filters,omitempty"`
+	Page    *Page             `json:"page,omitempty"`
+}
+
+// DocumentClicks Number of clicks on a document
+type DocumentClicks struct {
+	DocumentID string `json:"document_id"`
+	Clicks     int    `json:"clicks"`
+}
+
+// TopClicksResponse TopClicks response
+type TopClicksResponse struct {
+	Meta    ResponseMeta     `json:"meta"`
+	Results []DocumentClicks `json:"results"`
+}
+
+// KeyType API key type
+type KeyType = string
+
+const (
+	// PrivateKey API key type
+	PrivateKey KeyType = "private"
+	// SearchKey API key type
+	SearchKey KeyType = "search"
+	// AdminKey API key type
+	AdminKey KeyType = "admin"
+)
+
+// CredentialRequest Request for CreateCredential or UpdateCredential
+type CredentialRequest struct {
+	Name string  `json:"name"`
+	Type KeyType `json:"type"`
+	// Read permission of PrivateKey
+	Read bool `json:"read"`
+	// Write permission of PrivateKey
+	Write bool `json:"write"`
+	// Access to all engines of PrivateKey or SearchKey
+	AccessAllEngines bool `json:"access_all_engines"`
+	// Engines accessible by PrivateKey or SearchKey if not AccessAllEngines
+	Engines []string `json:"engines,omitempty"`
+}
+
+// MarshalJSON Include only fields applicable to key type
+func (r CredentialRequest) MarshalJSON() ([]byte, error) {
+	request := m{
+		"name": r.Name,
+		"type": r.Type,
+	}
+
+	if r.Type == PrivateKey {
+		request["read"] = r.Read
+		request["write"] = r.Write
+	}
+
+	if r.Type != AdminKey {
+		request["access_all_engines"] = r.AccessAllEngines
+		if !r.AccessAllEngines {
+			request["engines"] = r.Engines
+		}
+	}
+
+	return json.Marshal(request)
+}
+
+// CredentialDescription API key description
+type CredentialDescription struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Type             KeyType  `json:"type"`
+	Key              string   `json:"key"`
+	Read             bool     `json:"read"`
+	Write            bool     `json:"write"`
+	AccessAllEngines bool     `json:"access_all_engines"`
+	Engines          []string `json:"engines"`
+}
+
+// Request Request creating API key with the same name and permissions
+func (d CredentialDescription) Request() CredentialRequest {
+	return CredentialRequest{
+		Name:             d.Name,
+		Type:             d.Type,
+		Read:             d.Read,
+		Write:            d.Write,
+		AccessAllEngines: d.AccessAllEngines,
+		Engines:          d.Engines,
+	}
+}
+
+// CredentialResponse ListCredentials response
+type CredentialResponse struct {
+	Meta    ResponseMeta            `json:"meta"`
+	Results []CredentialDescription `json:"results"`
+}
+
 // API Error
 type Error struct {
 	Message    string   `json:"error"`
 	Messages   []string `json:"errors"`
 	StatusCode int      `json:"code"`
+	// Attempts of request including retries
+	Attempts int `json:"-"`
 }
 
 func (e *Error) Error() string {
@@ -263,3 +637,28 @@ func (e *Error) Error() string {
 
 	return fmt.Sprintf("HTTP [%d]", e.StatusCode)
 }
+
+// Is Match ErrRateLimited by status code
+func (e *Error) Is(target error) bool {
+	return target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests
+}
+
+// MultiSearchError Error reporting failed queries of MultiSearchDocuments
+type MultiSearchError struct {
+	// Errors by index of failed query
+	Errors map[int]error
+}
+
+func (e *MultiSearchError) Error() string {
+	indices := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	messages := make([]string, len(indices))
+	for i, index := range indices {
+		messages[i] = fmt.Sprintf("query %d: %s", index, e.Errors[index])
+	}
+	return strings.Join(messages, ", ")
+}
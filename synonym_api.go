@@ -0,0 +1,41 @@
+package appsearch
+
+import (
+	"context"
+	"net/http"
+)
+
+// List synonym sets with pagination
+func (c *client) ListSynonymSets(ctx context.Context, engineName string, page Page) (data SynonymSetResponse, err error) {
+	err = c.Call(ctx, m{"page": page}, &data, http.MethodGet, "engines/%s/synonyms", engineName)
+
+	return data, err
+}
+
+// List a synonym set by ID
+func (c *client) ListSynonymSet(ctx context.Context, engineName string, synonymSetID string) (data SynonymSet, err error) {
+	err = c.Call(ctx, nil, &data, http.MethodGet, "engines/%s/synonyms/%s", engineName, synonymSetID)
+
+	return data, err
+}
+
+// Create synonym set
+func (c *client) CreateSynonymSet(ctx context.Context, engineName string, request SynonymSetRequest) (data SynonymSet, err error) {
+	err = c.Call(ctx, request, &data, http.MethodPost, "engines/%s/synonyms", engineName)
+
+	return data, err
+}
+
+// Update (replace) synonyms of synonym set by ID
+func (c *client) UpdateSynonymSet(ctx context.Context, engineName string, synonymSetID string, request SynonymSetRequest) (data SynonymSet, err error) {
+	err = c.Call(ctx, request, &data, http.MethodPut, "engines/%s/synonyms/%s", engineName, synonymSetID)
+
+	return data, err
+}
+
+// Delete synonym set by ID
+func (c *client) DeleteSynonymSet(ctx context.Context, engineName string, synonymSetID string) (err error) {
+	err = c.Call(ctx, nil, nil, http.MethodDelete, "engines/%s/synonyms/%s", engineName, synonymSetID)
+
+	return
+}
@@ -0,0 +1,86 @@
+package appsearch
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSynonymAPI(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	c, err := Open(os.Getenv("APPSEARCH"))
+	require.NoError(t, err)
+
+	t.Run("Must create and list synonym set", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		set, err := c.CreateSynonymSet(ctx, engine.Name, SynonymSetRequest{
+			Synonyms: []string{"park", "reserve"},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, set.ID)
+
+		listed, err := c.ListSynonymSet(ctx, engine.Name, set.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, set, listed)
+
+		sets, err := c.ListSynonymSets(ctx, engine.Name, Page{1, 25})
+		require.NoError(t, err)
+		require.Contains(t, sets.Results, set)
+	})
+
+	t.Run("Must update synonym set", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		set, err := c.CreateSynonymSet(ctx, engine.Name, SynonymSetRequest{
+			Synonyms: []string{"park", "reserve"},
+		})
+		require.NoError(t, err)
+
+		updated, err := c.UpdateSynonymSet(ctx, engine.Name, set.ID, SynonymSetRequest{
+			Synonyms: []string{"park", "reserve", "preserve"},
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, SynonymSet{
+			ID:       set.ID,
+			Synonyms: []string{"park", "reserve", "preserve"},
+		}, updated)
+	})
+
+	t.Run("Must delete synonym set", func(t *testing.T) {
+		t.Parallel()
+		engine := createRandomEngine(c)
+		defer deleteEngine(c, engine)
+
+		set, err := c.CreateSynonymSet(ctx, engine.Name, SynonymSetRequest{
+			Synonyms: []string{"park", "reserve"},
+		})
+		require.NoError(t, err)
+
+		err = c.DeleteSynonymSet(ctx, engine.Name, set.ID)
+		require.NoError(t, err)
+
+		_, err = c.ListSynonymSet(ctx, engine.Name, set.ID)
+		require.ErrorIs(t, err, ErrSynonymSetDoesntExist)
+	})
+}
+
+func TestListSynonymSets(t *testing.T) {
+	c, requests := recordingClient(t, func(r recordedRequest) interface{} {
+		return SynonymSetResponse{}
+	})
+
+	_, err := c.ListSynonymSets(context.TODO(), "engine", Page{Page: 2, Size: 5})
+	require.NoError(t, err)
+	require.Len(t, requests(), 1)
+	require.Equal(t, "engines/engine/synonyms", requests()[0].Path)
+	require.JSONEq(t, `{"page": {"current": 2, "size": 5}}`, requests()[0].Body)
+}